@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+data "ontap_aggregates" "candidates" {
+  node          = "cluster-01"
+  min_available = 1099511627776
+}
+
+output "aggregates" {
+  value = {
+    for a in data.ontap_aggregates.candidates.aggregates : a.name => a.available
+  }
+}
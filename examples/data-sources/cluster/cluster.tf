@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+data "ontap_cluster" "this" {}
+
+data "ontap_cluster_nodes" "all" {}
+
+output "version" {
+  value = data.ontap_cluster.this.version
+}
+
+output "nodes_down" {
+  value = [for n in data.ontap_cluster_nodes.all.nodes : n.name if n.state != "up"]
+}
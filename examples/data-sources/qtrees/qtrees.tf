@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+data "ontap_qtrees" "homes" {
+  volume_uuid    = "a1c3e0a2-1f2c-11ed-9b2a-005056b0c3a1"
+  name           = "home_*"
+  security_style = "unix"
+}
+
+output "homes" {
+  value = data.ontap_qtrees.homes.qtrees[*].path
+}
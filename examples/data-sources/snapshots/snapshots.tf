@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+data "ontap_snapshots" "daily" {
+  volume_uuid   = "023b1262-6a22-4134-8972-33b370e5474c"
+  name          = "daily.*"
+  created_after = "2022-10-01T00:00:00Z"
+}
+
+output "snapshots" {
+  value = data.ontap_snapshots.daily.snapshots[*].name
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+data "ontap_svms" "tenants" {
+  name     = "tenant_*"
+  state    = "running"
+  protocol = "nfs"
+}
+
+resource "ontap_qos_policy" "tenant" {
+  for_each = { for svm in data.ontap_svms.tenants.svms : svm.name => svm }
+
+  svm  = each.key
+  name = "${each.key}_default"
+
+  fixed = {
+    max_throughput_iops = 5000
+  }
+}
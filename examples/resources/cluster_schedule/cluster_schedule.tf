@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_cluster_schedule" "nightly" {
+  name = "nightly"
+  type = "cron"
+  cron = {
+    minutes = [30]
+    hours   = [2]
+  }
+}
+
+resource "ontap_cluster_schedule" "every_15min" {
+  name     = "every_15min"
+  type     = "interval"
+  interval = "PT15M"
+}
@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_igroup" "esx_cluster" {
+  svm      = "svm_san"
+  name     = "esx_cluster"
+  protocol = "iscsi"
+  os_type  = "vmware"
+  initiators = [
+    "iqn.1998-01.com.vmware:esx01-4f8c2a1b",
+    "iqn.1998-01.com.vmware:esx02-7d3e9b0c",
+  ]
+}
+
+resource "ontap_lun" "datastore01" {
+  svm     = "svm_san"
+  name    = "/vol/vmware_ds/datastore01"
+  os_type = "vmware"
+  size    = 1099511627776
+}
+
+resource "ontap_lun_map" "datastore01" {
+  svm                 = "svm_san"
+  lun                 = ontap_lun.datastore01.name
+  igroup              = ontap_igroup.esx_cluster.name
+  logical_unit_number = 1
+  reporting_nodes     = ["cluster-01", "cluster-02"]
+}
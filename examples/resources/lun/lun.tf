@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_lun" "datastore01" {
+  svm               = "svm_san"
+  name              = "/vol/vmware_ds/datastore01"
+  os_type           = "vmware"
+  size              = 1099511627776
+  space_reservation = false
+  comment           = "VMware datastore"
+}
+
+data "ontap_lun" "db01" {
+  svm  = "svm_san"
+  name = "/vol/oracle/db01"
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_nvme_service" "svm_nvme" {
+  svm = "svm_nvme"
+}
+
+resource "ontap_nvme_namespace" "k8s_pv01" {
+  svm     = ontap_nvme_service.svm_nvme.svm
+  name    = "/vol/k8s/pv01"
+  os_type = "linux"
+  size    = 107374182400
+}
+
+resource "ontap_nvme_subsystem" "k8s_prod" {
+  svm     = ontap_nvme_service.svm_nvme.svm
+  name    = "k8s_prod"
+  os_type = "linux"
+  hosts = [
+    "nqn.2014-08.org.nvmexpress:uuid:2e4f8a1c-7b3d-4c9e-a6f1-0d5b8c2e9f14",
+    "nqn.2014-08.org.nvmexpress:uuid:9c1d3e5f-2a4b-4d6c-8e0f-1a3b5c7d9e2f",
+  ]
+  namespaces = [ontap_nvme_namespace.k8s_pv01.name]
+}
@@ -0,0 +1,51 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+provider "ontap" {
+  alias    = "dr"
+  hostname = "cluster-dr.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_cluster_peer" "to_dr" {
+  generate_passphrase = true
+  remote_ip_addresses = ["10.0.1.21", "10.0.1.22"]
+}
+
+resource "ontap_cluster_peer" "from_dr" {
+  provider            = ontap.dr
+  passphrase          = ontap_cluster_peer.to_dr.passphrase
+  remote_ip_addresses = ["10.0.0.21", "10.0.0.22"]
+}
+
+resource "ontap_svm_peer" "svm1" {
+  svm          = "svm1"
+  peer_svm     = "svm1_dr"
+  peer_cluster = "cluster-dr"
+  applications = ["snapmirror"]
+
+  depends_on = [ontap_cluster_peer.from_dr]
+}
+
+resource "ontap_svm_peer" "svm1_accept" {
+  provider     = ontap.dr
+  svm          = "svm1_dr"
+  peer_svm     = "svm1"
+  applications = ["snapmirror"]
+  accept       = true
+
+  depends_on = [ontap_svm_peer.svm1]
+}
@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_qos_policy" "gold" {
+  svm  = "svm_nas"
+  name = "gold"
+
+  fixed = {
+    min_throughput_iops = 1000
+    max_throughput_iops = 10000
+    max_throughput_mbps = 500
+  }
+}
+
+resource "ontap_qos_policy" "silver" {
+  svm  = "svm_nas"
+  name = "silver"
+
+  adaptive = {
+    expected_iops = 1024
+    peak_iops     = 2048
+    block_size    = "32k"
+  }
+}
+
+resource "ontap_qtree" "tenant_a" {
+  svm_uuid    = "8a2b7e64-1f2c-11ed-9b2a-005056b0c3a1"
+  volume_uuid = "a1c3e0a2-1f2c-11ed-9b2a-005056b0c3a1"
+  name        = "tenant_a"
+  qos_policy  = ontap_qos_policy.gold.name
+}
@@ -0,0 +1,50 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_s3_service" "svm_s3" {
+  svm           = "svm_s3"
+  name          = "s3.company.lan"
+  certificate   = "s3_company_lan"
+  http_enabled  = false
+  https_enabled = true
+  secure_port   = 443
+}
+
+resource "ontap_s3_user" "analytics" {
+  svm     = ontap_s3_service.svm_s3.svm
+  name    = "analytics"
+  comment = "Analytics team"
+}
+
+resource "ontap_s3_bucket" "analytics" {
+  svm              = ontap_s3_service.svm_s3.svm
+  name             = "analytics"
+  size             = 1099511627776
+  versioning_state = "enabled"
+
+  policy_statements = [
+    {
+      effect     = "allow"
+      actions    = ["*"]
+      principals = [ontap_s3_user.analytics.name]
+      resources  = ["analytics", "analytics/*"]
+    },
+  ]
+}
+
+output "analytics_secret_key" {
+  value     = ontap_s3_user.analytics.secret_key
+  sensitive = true
+}
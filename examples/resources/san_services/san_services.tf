@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+variable "chap_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "ontap_iscsi_service" "svm_san" {
+  svm          = "svm_san"
+  target_alias = "svm_san_iscsi"
+}
+
+resource "ontap_fcp_service" "svm_san" {
+  svm = "svm_san"
+}
+
+resource "ontap_iscsi_credentials" "default" {
+  svm                 = ontap_iscsi_service.svm_san.svm
+  initiator           = "default"
+  authentication_type = "deny"
+}
+
+resource "ontap_iscsi_credentials" "esx01" {
+  svm                 = ontap_iscsi_service.svm_san.svm
+  initiator           = "iqn.1998-01.com.vmware:esx01-4f8c2a1b"
+  authentication_type = "chap"
+  inbound_user        = "esx01"
+  inbound_password    = var.chap_secret
+}
@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster-dr.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_snapmirror_policy" "vault" {
+  name                        = "vault_daily_weekly"
+  svm                         = "svm1_dr"
+  type                        = "async"
+  comment                     = "Keep 7 daily and 4 weekly snapshots"
+  transfer_schedule           = "daily"
+  network_compression_enabled = true
+
+  retention = [
+    {
+      label = "daily"
+      count = 7
+    },
+    {
+      label = "weekly"
+      count = 4
+    },
+  ]
+}
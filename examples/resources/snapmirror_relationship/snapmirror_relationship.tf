@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster-dr.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_snapmirror_relationship" "vol1" {
+  source_path             = "svm1:vol1"
+  source_cluster          = "cluster"
+  destination_path        = "svm1_dr:vol1_dst"
+  policy                  = "MirrorAllSnapshots"
+  transfer_schedule       = "hourly"
+  wait_for_initialization = true
+
+  # Set to "broken_off" to fail over to the destination
+  state = "snapmirrored"
+}
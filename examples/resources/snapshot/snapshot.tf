@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    ontap = {
+      version = "0.1"
+      source  = "netapp/com/ontap"
+    }
+  }
+}
+
+provider "ontap" {
+  hostname = "cluster.company.lan"
+  username = "admin"
+  password = "Netapp01"
+}
+
+resource "ontap_snapshot" "pre_migration" {
+  volume_uuid      = "023b1262-6a22-4134-8972-33b370e5474c"
+  name             = "pre_migration"
+  comment          = "Taken before schema migration"
+  expiry_time      = "2022-12-31T00:00:00Z"
+  snapmirror_label = "manual"
+}
@@ -0,0 +1,226 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AggregatesDataSource{}
+
+func NewAggregatesDataSource() datasource.DataSource {
+	return &AggregatesDataSource{}
+}
+
+// AggregatesDataSource defines the data source implementation.
+type AggregatesDataSource struct {
+	client *ontap.Client
+}
+
+// AggregatesDataSourceModel describes the data source data model.
+type AggregatesDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Node         types.String `tfsdk:"node"`
+	MinAvailable types.Int64  `tfsdk:"min_available"`
+
+	Aggregates []AggregateDetailDataSourceModel `tfsdk:"aggregates"`
+}
+
+type AggregateDetailDataSourceModel struct {
+	UUID        types.String `tfsdk:"uuid"`
+	Name        types.String `tfsdk:"name"`
+	Node        types.String `tfsdk:"node"`
+	State       types.String `tfsdk:"state"`
+	Size        types.Int64  `tfsdk:"size"`
+	Used        types.Int64  `tfsdk:"used"`
+	Available   types.Int64  `tfsdk:"available"`
+	RAIDType    types.String `tfsdk:"raid_type"`
+	DiskClass   types.String `tfsdk:"disk_class"`
+	StorageType types.String `tfsdk:"storage_type"`
+}
+
+func (d *AggregatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aggregates"
+}
+
+func (d *AggregatesDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Aggregates of the cluster with their capacity, i.e. to choose where to place a volume",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the data source, always `aggregates`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"name": {
+				MarkdownDescription: "Only return aggregates matching this name, `*` can be used as a wildcard, i.e. `aggr_ssd_*`",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"node": {
+				MarkdownDescription: "Only return aggregates owned by this node",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"min_available": {
+				MarkdownDescription: "Only return aggregates with at least this many bytes available",
+				Type:                types.Int64Type,
+				Optional:            true,
+			},
+			"aggregates": {
+				MarkdownDescription: "Matching aggregates",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"uuid": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"node": {
+						MarkdownDescription: "Name of the node owning the aggregate",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"state": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"size": {
+						MarkdownDescription: "Usable size in bytes",
+						Type:                types.Int64Type,
+						Computed:            true,
+					},
+					"used": {
+						MarkdownDescription: "Used space in bytes",
+						Type:                types.Int64Type,
+						Computed:            true,
+					},
+					"available": {
+						MarkdownDescription: "Available space in bytes",
+						Type:                types.Int64Type,
+						Computed:            true,
+					},
+					"raid_type": {
+						MarkdownDescription: "RAID type, i.e. `raid_dp`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"disk_class": {
+						MarkdownDescription: "Class of the disks, i.e. `solid_state` or `capacity`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"storage_type": {
+						MarkdownDescription: "Storage tier of the aggregate, i.e. `ssd`, `hdd` or `hybrid`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *AggregatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AggregatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AggregatesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.Name.Null {
+		query.Set("name", ontap.QueryWildcard(data.Name.Value))
+	}
+	if !data.Node.Null {
+		query.Set("node.name", ontap.QueryLiteral(data.Node.Value))
+	}
+	if !data.MinAvailable.Null {
+		query.Set("space.block_storage.available", ontap.QueryAtLeast(data.MinAvailable.Value))
+	}
+
+	aggregates, err := d.client.WithContext(ctx).GetAggregates(query)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read aggregates, got error: %s", err))
+		return
+	}
+
+	data.ID = types.String{Value: "aggregates"}
+	data.Aggregates = []AggregateDetailDataSourceModel{}
+	for _, aggregate := range aggregates {
+		aggregate_model := AggregateDetailDataSourceModel{
+			UUID:        types.String{Value: aggregate.UUID},
+			Name:        types.String{Value: aggregate.Name},
+			Node:        types.String{Null: true},
+			State:       stringModel(aggregate.State),
+			Size:        types.Int64{Null: true},
+			Used:        types.Int64{Null: true},
+			Available:   types.Int64{Null: true},
+			RAIDType:    types.String{Null: true},
+			DiskClass:   types.String{Null: true},
+			StorageType: types.String{Null: true},
+		}
+		if aggregate.Node != nil {
+			aggregate_model.Node = stringModel(aggregate.Node.Name)
+		}
+		if aggregate.Space != nil {
+			aggregate_model.Size = types.Int64{Value: aggregate.Space.BlockStorage.Size}
+			aggregate_model.Used = types.Int64{Value: aggregate.Space.BlockStorage.Used}
+			aggregate_model.Available = types.Int64{Value: aggregate.Space.BlockStorage.Available}
+		}
+		if aggregate.BlockStorage != nil {
+			aggregate_model.StorageType = stringModel(aggregate.BlockStorage.StorageType)
+			if aggregate.BlockStorage.Primary != nil {
+				aggregate_model.RAIDType = stringModel(aggregate.BlockStorage.Primary.RAIDType)
+				aggregate_model.DiskClass = stringModel(aggregate.BlockStorage.Primary.DiskClass)
+			}
+		}
+
+		data.Aggregates = append(data.Aggregates, aggregate_model)
+	}
+
+	tflog.Trace(ctx, "read aggregates data source", map[string]interface{}{
+		"count": len(data.Aggregates),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
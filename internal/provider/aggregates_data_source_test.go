@@ -0,0 +1,70 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAggregatesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, all aggregates are returned with their capacity
+			{
+				Config: testAccAggregatesDataSourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.#", "4"),
+					resource.TestCheckResourceAttrSet("data.ontap_aggregates.test", "aggregates.0.uuid"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.name", "aggr1_01"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.node", "simulator-01"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.state", "online"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.size", "1099511627776"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.used", "274877906944"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.available", "824633720832"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.raid_type", "raid_dp"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.disk_class", "solid_state"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.storage_type", "ssd"),
+				),
+			},
+			// Node filtering
+			{
+				Config: testAccAggregatesDataSourceConfig(`node = "simulator-03"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.name", "aggr1_03"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.storage_type", "hdd"),
+				),
+			},
+			// Available space filtering, combined with name filtering
+			{
+				Config: testAccAggregatesDataSourceConfig(`
+  name          = "aggr1_0*"
+  min_available = 824633720832`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.#", "3"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.0.name", "aggr1_01"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.1.name", "aggr1_03"),
+					resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.2.name", "aggr1_04"),
+				),
+			},
+			// No match returns an empty list
+			{
+				Config: testAccAggregatesDataSourceConfig(`
+  node          = "simulator-02"
+  min_available = 1099511627776`),
+				Check: resource.TestCheckResourceAttr("data.ontap_aggregates.test", "aggregates.#", "0"),
+			},
+		},
+	})
+}
+
+func testAccAggregatesDataSourceConfig(filters string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+data "ontap_aggregates" "test" {
+  %s
+}
+`, filters)
+}
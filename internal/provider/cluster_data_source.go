@@ -0,0 +1,224 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ClusterDataSource{}
+
+func NewClusterDataSource() datasource.DataSource {
+	return &ClusterDataSource{}
+}
+
+// ClusterDataSource defines the data source implementation.
+type ClusterDataSource struct {
+	client *ontap.Client
+}
+
+// ClusterDataSourceModel describes the data source data model.
+type ClusterDataSourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	Name                 types.String                      `tfsdk:"name"`
+	Version              types.String                      `tfsdk:"version"`
+	VersionFull          types.String                      `tfsdk:"version_full"`
+	Location             types.String                      `tfsdk:"location"`
+	Contact              types.String                      `tfsdk:"contact"`
+	Timezone             types.String                      `tfsdk:"timezone"`
+	ManagementInterfaces []ClusterInterfaceDataSourceModel `tfsdk:"management_interfaces"`
+	DNSDomains           []types.String                    `tfsdk:"dns_domains"`
+	NameServers          []types.String                    `tfsdk:"name_servers"`
+	NTPServers           []types.String                    `tfsdk:"ntp_servers"`
+}
+
+type ClusterInterfaceDataSourceModel struct {
+	UUID    types.String `tfsdk:"uuid"`
+	Name    types.String `tfsdk:"name"`
+	Address types.String `tfsdk:"address"`
+}
+
+func (d *ClusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+func (d *ClusterDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The cluster the provider is connected to",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the data source, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"uuid": {
+				MarkdownDescription: "Cluster UUID",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"name": {
+				MarkdownDescription: "Cluster name",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"version": {
+				MarkdownDescription: "ONTAP version of the cluster, i.e. `9.11.1`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"version_full": {
+				MarkdownDescription: "Full ONTAP release string, i.e. `NetApp Release 9.11.1: ...`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"location": {
+				MarkdownDescription: "Cluster location",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"contact": {
+				MarkdownDescription: "Cluster contact",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"timezone": {
+				MarkdownDescription: "Cluster timezone, i.e. `Europe/Paris`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"management_interfaces": {
+				MarkdownDescription: "Cluster management interfaces",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"uuid": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"address": {
+						MarkdownDescription: "IP address of the interface",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+				}),
+			},
+			"dns_domains": {
+				MarkdownDescription: "DNS search domains",
+				Type:                types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"name_servers": {
+				MarkdownDescription: "DNS servers",
+				Type:                types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+			"ntp_servers": {
+				MarkdownDescription: "NTP servers",
+				Type:                types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (d *ClusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cluster, err := d.client.WithContext(ctx).GetCluster()
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster, got error: %s", err))
+		return
+	}
+
+	data.UUID = types.String{Value: cluster.UUID}
+	data.ID = data.UUID
+	data.Name = types.String{Value: cluster.Name}
+	data.Version = clusterVersionModel(cluster.Version)
+	data.VersionFull = types.String{Null: true}
+	if cluster.Version != nil {
+		data.VersionFull = stringModel(cluster.Version.Full)
+	}
+	data.Location = stringModel(cluster.Location)
+	data.Contact = stringModel(cluster.Contact)
+	data.Timezone = types.String{Null: true}
+	if cluster.Timezone != nil {
+		data.Timezone = stringModel(cluster.Timezone.Name)
+	}
+
+	data.ManagementInterfaces = []ClusterInterfaceDataSourceModel{}
+	for _, i := range cluster.ManagementInterfaces {
+		address := types.String{Null: true}
+		if i.IP != nil {
+			address = stringModel(i.IP.Address)
+		}
+		data.ManagementInterfaces = append(data.ManagementInterfaces, ClusterInterfaceDataSourceModel{
+			UUID:    stringModel(i.UUID),
+			Name:    types.String{Value: i.Name},
+			Address: address,
+		})
+	}
+
+	data.DNSDomains = stringListModel(cluster.DNSDomains)
+	data.NameServers = stringListModel(cluster.NameServers)
+	data.NTPServers = stringListModel(cluster.NTPServers)
+
+	tflog.Trace(ctx, "read cluster data source", map[string]interface{}{
+		"name":    data.Name.Value,
+		"version": data.Version.Value,
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// clusterVersionModel returns the version of a cluster or node as
+// generation.major.minor
+func clusterVersionModel(version *ontap.ClusterVersion) types.String {
+	if version == nil {
+		return types.String{Null: true}
+	}
+
+	return types.String{Value: version.String()}
+}
@@ -0,0 +1,38 @@
+package ontap
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccClusterDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProviderConfig() + `
+data "ontap_cluster" "test" {
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "uuid", "00000000-0000-4000-8000-000000000000"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "name", "simulator"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "version", "9.11.1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "version_full", "NetApp Release 9.11.1: ontaptest simulator"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "location", "lab"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "contact", "storage@example.com"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "timezone", "Etc/UTC"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "management_interfaces.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "management_interfaces.0.name", "cluster_mgmt"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "management_interfaces.0.address", "192.0.2.10"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "dns_domains.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "name_servers.#", "2"),
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "ntp_servers.1", "ntp2.example.com"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,241 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ClusterNodesDataSource{}
+
+func NewClusterNodesDataSource() datasource.DataSource {
+	return &ClusterNodesDataSource{}
+}
+
+// ClusterNodesDataSource defines the data source implementation.
+type ClusterNodesDataSource struct {
+	client *ontap.Client
+}
+
+// ClusterNodesDataSourceModel describes the data source data model.
+type ClusterNodesDataSourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+
+	Nodes []ClusterNodeDataSourceModel `tfsdk:"nodes"`
+}
+
+type ClusterNodeDataSourceModel struct {
+	UUID            types.String   `tfsdk:"uuid"`
+	Name            types.String   `tfsdk:"name"`
+	Model           types.String   `tfsdk:"model"`
+	SerialNumber    types.String   `tfsdk:"serial_number"`
+	SystemID        types.String   `tfsdk:"system_id"`
+	Location        types.String   `tfsdk:"location"`
+	Version         types.String   `tfsdk:"version"`
+	Uptime          types.Int64    `tfsdk:"uptime"`
+	State           types.String   `tfsdk:"state"`
+	Membership      types.String   `tfsdk:"membership"`
+	HAEnabled       types.Bool     `tfsdk:"ha_enabled"`
+	HAPartners      []types.String `tfsdk:"ha_partners"`
+	TakeoverState   types.String   `tfsdk:"takeover_state"`
+	GivebackState   types.String   `tfsdk:"giveback_state"`
+	OverTemperature types.String   `tfsdk:"over_temperature"`
+}
+
+func (d *ClusterNodesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_nodes"
+}
+
+func (d *ClusterNodesDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nodes of the cluster with their hardware and health information",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the data source, always `nodes`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"name": {
+				MarkdownDescription: "Only return nodes matching this name, `*` can be used as a wildcard",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"nodes": {
+				MarkdownDescription: "Matching nodes",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"uuid": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"model": {
+						MarkdownDescription: "Controller model, i.e. `AFF-A400`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"serial_number": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"system_id": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"location": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"version": {
+						MarkdownDescription: "ONTAP version of the node, i.e. `9.11.1`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"uptime": {
+						MarkdownDescription: "Uptime in seconds",
+						Type:                types.Int64Type,
+						Computed:            true,
+					},
+					"state": {
+						MarkdownDescription: "Node state, i.e. `up`, `down` or `taken_over`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"membership": {
+						MarkdownDescription: "Cluster membership, i.e. `member` or `available`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"ha_enabled": {
+						MarkdownDescription: "Whether storage failover is enabled",
+						Type:                types.BoolType,
+						Computed:            true,
+					},
+					"ha_partners": {
+						MarkdownDescription: "Names of the HA partners of the node",
+						Type:                types.ListType{ElemType: types.StringType},
+						Computed:            true,
+					},
+					"takeover_state": {
+						MarkdownDescription: "State of the last takeover, i.e. `not_attempted` or `in_takeover`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"giveback_state": {
+						MarkdownDescription: "State of the last giveback, i.e. `nothing_to_giveback`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"over_temperature": {
+						MarkdownDescription: "Temperature status of the controller, `normal` or `over`",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *ClusterNodesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterNodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterNodesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.Name.Null {
+		query.Set("name", ontap.QueryWildcard(data.Name.Value))
+	}
+
+	nodes, err := d.client.WithContext(ctx).GetClusterNodes(query)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster nodes, got error: %s", err))
+		return
+	}
+
+	data.ID = types.String{Value: "nodes"}
+	data.Nodes = []ClusterNodeDataSourceModel{}
+	for _, node := range nodes {
+		node_model := ClusterNodeDataSourceModel{
+			UUID:            types.String{Value: node.UUID},
+			Name:            types.String{Value: node.Name},
+			Model:           stringModel(node.Model),
+			SerialNumber:    stringModel(node.SerialNumber),
+			SystemID:        stringModel(node.SystemID),
+			Location:        stringModel(node.Location),
+			Version:         clusterVersionModel(node.Version),
+			Uptime:          types.Int64{Value: node.Uptime},
+			State:           stringModel(node.State),
+			Membership:      stringModel(node.Membership),
+			HAEnabled:       types.Bool{Null: true},
+			HAPartners:      []types.String{},
+			TakeoverState:   types.String{Null: true},
+			GivebackState:   types.String{Null: true},
+			OverTemperature: types.String{Null: true},
+		}
+		if node.HA != nil {
+			node_model.HAEnabled = types.Bool{Value: node.HA.Enabled}
+			for _, partner := range node.HA.Partners {
+				node_model.HAPartners = append(node_model.HAPartners, types.String{Value: partner.Name})
+			}
+			if node.HA.Takeover != nil {
+				node_model.TakeoverState = stringModel(node.HA.Takeover.State)
+			}
+			if node.HA.Giveback != nil {
+				node_model.GivebackState = stringModel(node.HA.Giveback.State)
+			}
+		}
+		if node.Controller != nil {
+			node_model.OverTemperature = stringModel(node.Controller.OverTemperature)
+		}
+
+		data.Nodes = append(data.Nodes, node_model)
+	}
+
+	tflog.Trace(ctx, "read cluster nodes data source", map[string]interface{}{
+		"count": len(data.Nodes),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,55 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccClusterNodesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing, the nodes are returned with their HA partner
+			{
+				Config: testAccClusterNodesDataSourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.#", "4"),
+					resource.TestCheckResourceAttrSet("data.ontap_cluster_nodes.test", "nodes.0.uuid"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.name", "simulator-01"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.model", "SIMBOX"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.serial_number", "4082368-50-1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.version", "9.11.1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.uptime", "86400"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.state", "up"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.membership", "member"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.ha_enabled", "true"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.ha_partners.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.ha_partners.0", "simulator-02"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.takeover_state", "not_attempted"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.giveback_state", "nothing_to_giveback"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.over_temperature", "normal"),
+				),
+			},
+			// Name filtering
+			{
+				Config: testAccClusterNodesDataSourceConfig(`name = "*-04"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.name", "simulator-04"),
+					resource.TestCheckResourceAttr("data.ontap_cluster_nodes.test", "nodes.0.ha_partners.0", "simulator-03"),
+				),
+			},
+		},
+	})
+}
+
+func testAccClusterNodesDataSourceConfig(filters string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+data "ontap_cluster_nodes" "test" {
+  %s
+}
+`, filters)
+}
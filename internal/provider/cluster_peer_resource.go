@@ -0,0 +1,392 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ClusterPeerResource{}
+var _ resource.ResourceWithImportState = &ClusterPeerResource{}
+var _ resource.ResourceWithValidateConfig = &ClusterPeerResource{}
+
+func NewClusterPeerResource() resource.Resource {
+	return &ClusterPeerResource{}
+}
+
+// ClusterPeerResource defines the resource implementation.
+type ClusterPeerResource struct {
+	client *ontap.Client
+}
+
+// ClusterPeerResourceModel describes the resource data model.
+type ClusterPeerResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	Name                 types.String   `tfsdk:"name"`
+	RemoteIPAddresses    []types.String `tfsdk:"remote_ip_addresses"`
+	GeneratePassphrase   types.Bool     `tfsdk:"generate_passphrase"`
+	Passphrase           types.String   `tfsdk:"passphrase"`
+	PassphraseExpiryTime types.String   `tfsdk:"passphrase_expiry_time"`
+	IPSpace              types.String   `tfsdk:"ipspace"`
+	Encryption           types.String   `tfsdk:"encryption"`
+	PeerApplications     []types.String `tfsdk:"peer_applications"`
+	RemoteClusterName    types.String   `tfsdk:"remote_cluster_name"`
+	Status               types.String   `tfsdk:"status"`
+}
+
+func (r *ClusterPeerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_peer"
+}
+
+func (r *ClusterPeerResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A cluster peer. Peering is established by creating a peer with `generate_passphrase` on one cluster, and a peer using that `passphrase` on the remote cluster, through a second provider alias.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the cluster peer, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Cluster peer UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Name of the peer, defaults to the name of the remote cluster",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"remote_ip_addresses": {
+				MarkdownDescription: "Intercluster addresses of the remote cluster",
+				Type:                types.ListType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"generate_passphrase": {
+				MarkdownDescription: "Generate a passphrase to be used by the remote cluster to accept the peering",
+				Type:                types.BoolType,
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"passphrase": {
+				MarkdownDescription: "Peering passphrase, either generated by this cluster or the one generated by the remote cluster",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"passphrase_expiry_time": {
+				MarkdownDescription: "Time after which a generated passphrase can't be used anymore",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringIsRFC3339(),
+				},
+			},
+			"ipspace": {
+				MarkdownDescription: "IPspace of the local intercluster interfaces, defaults to `Default`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"encryption": {
+				MarkdownDescription: "Encryption of the peering traffic, `tls_psk` or `none`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("tls_psk", "none"),
+				},
+			},
+			"peer_applications": {
+				MarkdownDescription: "Applications allowed to use the peering, i.e. `snapmirror` and `flexcache`",
+				Type:                types.ListType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"remote_cluster_name": {
+				MarkdownDescription: "Name of the remote cluster",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"status": {
+				MarkdownDescription: "Availability of the peer, i.e. `available`, `pending` or `unavailable`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *ClusterPeerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var generatePassphrase types.Bool
+	var passphrase types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("generate_passphrase"), &generatePassphrase)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passphrase"), &passphrase)...)
+
+	if resp.Diagnostics.HasError() || generatePassphrase.Unknown || passphrase.Unknown {
+		return
+	}
+
+	if generatePassphrase.Value && !passphrase.Null {
+		resp.Diagnostics.AddAttributeError(path.Root("passphrase"), "Invalid Attribute Combination", "passphrase can't be set when generate_passphrase is true")
+	}
+	if !generatePassphrase.Value && passphrase.Null {
+		resp.Diagnostics.AddAttributeError(path.Root("passphrase"), "Missing Attribute", "passphrase is required unless generate_passphrase is true")
+	}
+}
+
+func (r *ClusterPeerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterPeerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ClusterPeerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peer := data.toClusterPeer()
+
+	peer.Authentication = &ontap.ClusterPeerAuthentication{
+		GeneratePassphrase: data.GeneratePassphrase.Value,
+	}
+	if !data.GeneratePassphrase.Value {
+		peer.Authentication.Passphrase = data.Passphrase.Value
+	}
+	if !data.PassphraseExpiryTime.Null && !data.PassphraseExpiryTime.Unknown {
+		peer.Authentication.ExpiryTime = data.PassphraseExpiryTime.Value
+	}
+	if !data.IPSpace.Null && !data.IPSpace.Unknown {
+		peer.IPSpace = &ontap.UUIDRef{Name: data.IPSpace.Value}
+	}
+
+	created_peer, err := r.client.WithContext(ctx).CreateClusterPeer(&peer)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create cluster peer, got error: %s", err))
+		return
+	}
+
+	data.fromClusterPeer(created_peer)
+
+	// A generated passphrase is only returned on creation. Without it the
+	// peer is still saved so that it is tainted and replaced instead of
+	// failing the next apply as a duplicate
+	if data.GeneratePassphrase.Value {
+		if created_peer.Authentication == nil || created_peer.Authentication.Passphrase == "" {
+			data.Passphrase = types.String{Value: ""}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Cluster peer %s was created but no generated passphrase was returned", created_peer.UUID))
+			return
+		}
+
+		data.Passphrase = types.String{Value: created_peer.Authentication.Passphrase}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterPeerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ClusterPeerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peer, err := r.client.WithContext(ctx).GetClusterPeer(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster peer, got error: %s", err))
+		return
+	}
+
+	data.fromClusterPeer(peer)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterPeerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ClusterPeerResourceModel
+	var state *ClusterPeerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peer := plan.toClusterPeer()
+	peer.UUID = state.UUID.Value
+
+	updated_peer, err := r.client.WithContext(ctx).UpdateClusterPeer(&peer)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update cluster peer, got error: %s", err))
+		return
+	}
+
+	plan.fromClusterPeer(updated_peer)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ClusterPeerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ClusterPeerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peer := ontap.ClusterPeer{}
+	peer.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteClusterPeer(&peer)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete cluster peer, got error: %s", err))
+		return
+	}
+}
+
+func (r *ClusterPeerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+// toClusterPeer returns the modifiable properties of the peer,
+// authentication being only set on creation
+func (data *ClusterPeerResourceModel) toClusterPeer() ontap.ClusterPeer {
+	peer := ontap.ClusterPeer{}
+	if !data.Name.Unknown {
+		peer.Name = data.Name.Value
+	}
+	if data.RemoteIPAddresses != nil {
+		peer.Remote = &ontap.ClusterPeerRemote{
+			IPAddresses: stringListValues(data.RemoteIPAddresses),
+		}
+	}
+	if !data.Encryption.Null && !data.Encryption.Unknown {
+		peer.Encryption = &ontap.ClusterPeerEncryption{Proposed: data.Encryption.Value}
+	}
+	peer.PeerApplications = stringListValues(data.PeerApplications)
+
+	return peer
+}
+
+func (data *ClusterPeerResourceModel) fromClusterPeer(peer *ontap.ClusterPeer) {
+	data.UUID = types.String{Value: peer.UUID}
+	data.ID = data.UUID
+	data.Name = types.String{Value: peer.Name}
+
+	data.RemoteClusterName = types.String{Null: true}
+	if peer.Remote != nil {
+		data.RemoteClusterName = stringModel(peer.Remote.Name)
+		// Addresses are discovered by ONTAP once peered, only track them when
+		// configured
+		if data.RemoteIPAddresses != nil {
+			data.RemoteIPAddresses = stringListModel(peer.Remote.IPAddresses)
+		}
+	}
+
+	expiry_time := data.PassphraseExpiryTime
+	data.PassphraseExpiryTime = types.String{Null: true}
+	if peer.Authentication != nil {
+		data.PassphraseExpiryTime = timeModel(expiry_time, peer.Authentication.ExpiryTime)
+	}
+
+	data.IPSpace = types.String{Null: true}
+	if peer.IPSpace != nil {
+		data.IPSpace = stringModel(peer.IPSpace.Name)
+	}
+
+	data.Encryption = types.String{Null: true}
+	if peer.Encryption != nil {
+		data.Encryption = stringModel(peer.Encryption.State)
+	}
+
+	// ONTAP assigns default applications, only track them when configured
+	if data.PeerApplications != nil {
+		data.PeerApplications = stringListModel(peer.PeerApplications)
+	}
+
+	data.Status = types.String{Null: true}
+	if peer.Status != nil {
+		data.Status = stringModel(peer.Status.State)
+	}
+
+	// Passphrases are never returned by ONTAP, the value from the plan or
+	// prior state is kept
+	if data.Passphrase.Unknown {
+		data.Passphrase = types.String{Null: true}
+	}
+}
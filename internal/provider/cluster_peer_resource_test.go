@@ -0,0 +1,93 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccClusterPeerResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckClusterPeerDestroyed("peer_generated", "peer_remote"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the second peer uses the passphrase
+			// generated by the first one
+			{
+				Config: testAccClusterPeerResourceConfig(`["snapmirror"]`, "none"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_cluster_peer.generated", "uuid"),
+					resource.TestCheckResourceAttrSet("ontap_cluster_peer.generated", "passphrase"),
+					resource.TestCheckResourceAttrSet("ontap_cluster_peer.generated", "passphrase_expiry_time"),
+					resource.TestCheckResourceAttr("ontap_cluster_peer.generated", "ipspace", "Default"),
+					resource.TestCheckResourceAttr("ontap_cluster_peer.generated", "peer_applications.#", "1"),
+					resource.TestCheckResourceAttrPair("ontap_cluster_peer.remote", "passphrase", "ontap_cluster_peer.generated", "passphrase"),
+					resource.TestCheckResourceAttr("ontap_cluster_peer.remote", "status", "available"),
+					resource.TestCheckResourceAttr("ontap_cluster_peer.remote", "encryption", "none"),
+					resource.TestCheckResourceAttrSet("ontap_cluster_peer.remote", "remote_cluster_name"),
+					testAccCheckClusterPeerPassphraseNotStored("peer_generated"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccClusterPeerResourceConfig(`["snapmirror", "flexcache"]`, "tls_psk"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_cluster_peer.generated", "peer_applications.#", "2"),
+					resource.TestCheckResourceAttr("ontap_cluster_peer.remote", "encryption", "tls_psk"),
+				),
+			},
+			// ImportState testing, passphrases aren't returned by ONTAP
+			{
+				ResourceName:            "ontap_cluster_peer.remote",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passphrase", "remote_ip_addresses"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccClusterPeerResourceConfig(peer_applications string, encryption string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_cluster_peer" "generated" {
+  name                = "peer_generated"
+  generate_passphrase = true
+  peer_applications   = %s
+}
+
+resource "ontap_cluster_peer" "remote" {
+  name                = "peer_remote"
+  passphrase          = ontap_cluster_peer.generated.passphrase
+  remote_ip_addresses = ["192.0.2.10", "192.0.2.11"]
+  encryption          = %q
+}
+`, peer_applications, encryption)
+}
+
+func testAccCheckClusterPeerPassphraseNotStored(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		peer := testAccSimulator.ClusterPeer(name)
+		if peer == nil {
+			return fmt.Errorf("cluster peer %s doesn't exist", name)
+		}
+		if testAccRecordField(peer, "authentication.passphrase") != "" {
+			return fmt.Errorf("cluster peer %s returns its passphrase", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckClusterPeerDestroyed(names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.ClusterPeer(name) != nil {
+				return fmt.Errorf("cluster peer %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
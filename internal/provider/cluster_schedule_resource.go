@@ -0,0 +1,347 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ClusterScheduleResource{}
+var _ resource.ResourceWithImportState = &ClusterScheduleResource{}
+var _ resource.ResourceWithValidateConfig = &ClusterScheduleResource{}
+
+func NewClusterScheduleResource() resource.Resource {
+	return &ClusterScheduleResource{}
+}
+
+// ClusterScheduleResource defines the resource implementation.
+type ClusterScheduleResource struct {
+	client *ontap.Client
+}
+
+// ClusterScheduleResourceModel describes the resource data model.
+type ClusterScheduleResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	Name     types.String                      `tfsdk:"name"`
+	Type     types.String                      `tfsdk:"type"`
+	Cron     *ClusterScheduleCronResourceModel `tfsdk:"cron"`
+	Interval types.String                      `tfsdk:"interval"`
+}
+
+type ClusterScheduleCronResourceModel struct {
+	Minutes  []types.Int64 `tfsdk:"minutes"`
+	Hours    []types.Int64 `tfsdk:"hours"`
+	Days     []types.Int64 `tfsdk:"days"`
+	Weekdays []types.Int64 `tfsdk:"weekdays"`
+	Months   []types.Int64 `tfsdk:"months"`
+}
+
+// ISO-8601 durations as accepted by ONTAP for interval schedules, i.e. P1DT2H3M4S
+var iso8601DurationRegexp = regexp.MustCompile(`^P(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?$`)
+
+func (r *ClusterScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_schedule"
+}
+
+func (r *ClusterScheduleResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A cluster job schedule, used by snapshot policies and SnapMirror relationships",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the schedule, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Schedule UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Schedule name",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"type": {
+				MarkdownDescription: "Schedule type, `cron` or `interval`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("cron", "interval"),
+				},
+			},
+			"cron": {
+				MarkdownDescription: "Cron schedule definition, an omitted field matches every value",
+				Optional:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"minutes": {
+						MarkdownDescription: "Minutes of the hour (0-59)",
+						Type:                types.ListType{ElemType: types.Int64Type},
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64ListBetween(0, 59),
+						},
+					},
+					"hours": {
+						MarkdownDescription: "Hours of the day (0-23)",
+						Type:                types.ListType{ElemType: types.Int64Type},
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64ListBetween(0, 23),
+						},
+					},
+					"days": {
+						MarkdownDescription: "Days of the month (1-31)",
+						Type:                types.ListType{ElemType: types.Int64Type},
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64ListBetween(1, 31),
+						},
+					},
+					"weekdays": {
+						MarkdownDescription: "Days of the week, Sunday being 0 (0-6)",
+						Type:                types.ListType{ElemType: types.Int64Type},
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64ListBetween(0, 6),
+						},
+					},
+					"months": {
+						MarkdownDescription: "Months of the year (1-12)",
+						Type:                types.ListType{ElemType: types.Int64Type},
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							int64ListBetween(1, 12),
+						},
+					},
+				}),
+			},
+			"interval": {
+				MarkdownDescription: "Interval between runs as an ISO-8601 duration, i.e. `PT30M`",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringMatches(iso8601DurationRegexp, "must be an ISO-8601 duration like PT30M or P1DT12H"),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *ClusterScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var scheduleType types.String
+	var cron types.Object
+	var interval types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &scheduleType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cron"), &cron)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("interval"), &interval)...)
+
+	if resp.Diagnostics.HasError() || scheduleType.Null || scheduleType.Unknown {
+		return
+	}
+
+	switch scheduleType.Value {
+	case "cron":
+		if !interval.Null {
+			resp.Diagnostics.AddAttributeError(path.Root("interval"), "Invalid Attribute Combination", "interval can't be set on a cron schedule")
+		}
+		if cron.Null {
+			resp.Diagnostics.AddAttributeError(path.Root("cron"), "Missing Attribute", "cron is required on a cron schedule")
+		}
+	case "interval":
+		if !cron.Null {
+			resp.Diagnostics.AddAttributeError(path.Root("cron"), "Invalid Attribute Combination", "cron can't be set on an interval schedule")
+		}
+		if interval.Null {
+			resp.Diagnostics.AddAttributeError(path.Root("interval"), "Missing Attribute", "interval is required on an interval schedule")
+		}
+	}
+}
+
+func (r *ClusterScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ClusterScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule := data.toSchedule()
+
+	created_schedule, err := r.client.WithContext(ctx).CreateSchedule(&schedule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create schedule, got error: %s", err))
+		return
+	}
+
+	data.fromSchedule(created_schedule)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ClusterScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.WithContext(ctx).GetSchedule(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read schedule, got error: %s", err))
+		return
+	}
+
+	data.fromSchedule(schedule)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ClusterScheduleResourceModel
+	var state *ClusterScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule := plan.toSchedule()
+	schedule.UUID = state.UUID.Value
+
+	updated_schedule, err := r.client.WithContext(ctx).UpdateSchedule(&schedule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schedule, got error: %s", err))
+		return
+	}
+
+	plan.fromSchedule(updated_schedule)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ClusterScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ClusterScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule := ontap.Schedule{}
+	schedule.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteSchedule(&schedule)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete schedule, got error: %s", err))
+		return
+	}
+}
+
+func (r *ClusterScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *ClusterScheduleResourceModel) toSchedule() ontap.Schedule {
+	schedule := ontap.Schedule{}
+	schedule.Name = data.Name.Value
+	schedule.Type = data.Type.Value
+
+	if data.Cron != nil {
+		schedule.Cron = &ontap.ScheduleCron{
+			Minutes:  int64ListValues(data.Cron.Minutes),
+			Hours:    int64ListValues(data.Cron.Hours),
+			Days:     int64ListValues(data.Cron.Days),
+			Weekdays: int64ListValues(data.Cron.Weekdays),
+			Months:   int64ListValues(data.Cron.Months),
+		}
+	}
+
+	if !data.Interval.Null {
+		schedule.Interval = data.Interval.Value
+	}
+
+	return schedule
+}
+
+func (data *ClusterScheduleResourceModel) fromSchedule(schedule *ontap.Schedule) {
+	data.UUID = types.String{Value: schedule.UUID}
+	data.ID = data.UUID
+	data.Name = types.String{Value: schedule.Name}
+	data.Type = types.String{Value: schedule.Type}
+
+	data.Cron = nil
+	if schedule.Cron != nil {
+		data.Cron = &ClusterScheduleCronResourceModel{
+			Minutes:  int64ListModel(schedule.Cron.Minutes),
+			Hours:    int64ListModel(schedule.Cron.Hours),
+			Days:     int64ListModel(schedule.Cron.Days),
+			Weekdays: int64ListModel(schedule.Cron.Weekdays),
+			Months:   int64ListModel(schedule.Cron.Months),
+		}
+	}
+
+	data.Interval = types.String{Null: schedule.Interval == "", Value: schedule.Interval}
+}
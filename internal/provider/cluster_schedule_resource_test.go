@@ -0,0 +1,103 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccClusterScheduleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckScheduleDestroyed("sched_acc"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccClusterScheduleResourceConfig(`
+  cron = {
+    minutes = [0, 30]
+    hours   = [2]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_cluster_schedule.test", "name", "sched_acc"),
+					resource.TestCheckResourceAttrSet("ontap_cluster_schedule.test", "uuid"),
+					resource.TestCheckResourceAttr("ontap_cluster_schedule.test", "cron.minutes.#", "2"),
+					resource.TestCheckResourceAttr("ontap_cluster_schedule.test", "cron.hours.0", "2"),
+					resource.TestCheckNoResourceAttr("ontap_cluster_schedule.test", "cron.days"),
+				),
+			},
+			// Removing a cron field matches every value again
+			{
+				Config: testAccClusterScheduleResourceConfig(`
+  cron = {
+    minutes = [15]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_cluster_schedule.test", "cron.minutes.0", "15"),
+					resource.TestCheckNoResourceAttr("ontap_cluster_schedule.test", "cron.hours"),
+					testAccCheckScheduleCronField("sched_acc", "hours", false),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_cluster_schedule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccClusterScheduleResourceCronRequired(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccClusterScheduleResourceConfig(""),
+				ExpectError: regexp.MustCompile("cron is required on a cron schedule"),
+			},
+		},
+	})
+}
+
+func testAccClusterScheduleResourceConfig(cron string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_cluster_schedule" "test" {
+  name = "sched_acc"
+  type = "cron"
+%s
+}
+`, cron)
+}
+
+func testAccCheckScheduleCronField(name string, field string, set bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		schedule := testAccSimulator.Schedule(name)
+		if schedule == nil {
+			return fmt.Errorf("schedule %s doesn't exist", name)
+		}
+
+		cron, _ := schedule["cron"].(map[string]interface{})
+		if _, ok := cron[field]; ok != set {
+			return fmt.Errorf("expected cron field %s of schedule %s to be set: %t, got %v", field, name, set, cron)
+		}
+		return nil
+	}
+}
+
+func testAccCheckScheduleDestroyed(names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.Schedule(name) != nil {
+				return fmt.Errorf("schedule %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
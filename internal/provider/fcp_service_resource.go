@@ -0,0 +1,233 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &FCPServiceResource{}
+var _ resource.ResourceWithImportState = &FCPServiceResource{}
+
+func NewFCPServiceResource() resource.Resource {
+	return &FCPServiceResource{}
+}
+
+// FCPServiceResource defines the resource implementation.
+type FCPServiceResource struct {
+	client *ontap.Client
+}
+
+// FCPServiceResourceModel describes the resource data model.
+type FCPServiceResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM        types.String `tfsdk:"svm"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	TargetName types.String `tfsdk:"target_name"`
+}
+
+func (r *FCPServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fcp_service"
+}
+
+func (r *FCPServiceResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The FC service of an SVM",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the FC service, same as svm_uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"enabled": {
+				MarkdownDescription: "Whether the FC service is running. Defaults to `true`",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"target_name": {
+				MarkdownDescription: "World wide node name of the FC target",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *FCPServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FCPServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *FCPServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := data.toFCPService()
+	service.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+
+	created_service, err := r.client.WithContext(ctx).CreateFCPService(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create fcp service, got error: %s", err))
+		return
+	}
+
+	data.fromFCPService(created_service)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FCPServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *FCPServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.WithContext(ctx).GetFCPService(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read fcp service, got error: %s", err))
+		return
+	}
+
+	data.fromFCPService(service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FCPServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *FCPServiceResourceModel
+	var state *FCPServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := plan.toFCPService()
+	service.SVM = &ontap.UUIDRef{UUID: state.SVMUUID.Value}
+
+	updated_service, err := r.client.WithContext(ctx).UpdateFCPService(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update fcp service, got error: %s", err))
+		return
+	}
+
+	plan.fromFCPService(updated_service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *FCPServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *FCPServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteFCPService(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete fcp service, got error: %s", err))
+		return
+	}
+}
+
+func (r *FCPServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("svm_uuid"), req, resp)
+}
+
+func (data *FCPServiceResourceModel) toFCPService() ontap.FCPService {
+	service := ontap.FCPService{}
+	if !data.Enabled.Null && !data.Enabled.Unknown {
+		service.Enabled = &data.Enabled.Value
+	}
+
+	return service
+}
+
+func (data *FCPServiceResourceModel) fromFCPService(service *ontap.FCPService) {
+	if service.SVM != nil {
+		data.SVMUUID = types.String{Value: service.SVM.UUID}
+		data.SVM = types.String{Value: service.SVM.Name}
+	}
+	data.ID = data.SVMUUID
+	data.Enabled = boolPointerModel(service.Enabled)
+
+	data.TargetName = types.String{Null: true}
+	if service.Target != nil {
+		data.TargetName = stringModel(service.Target.Name)
+	}
+}
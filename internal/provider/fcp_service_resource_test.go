@@ -0,0 +1,55 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFCPServiceResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_fcp")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSANServiceDestroyed("fcp", "svm_fcp"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccFCPServiceResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_fcp_service.test", "svm_uuid"),
+					resource.TestCheckResourceAttr("ontap_fcp_service.test", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("ontap_fcp_service.test", "target_name"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccFCPServiceResourceConfig(false),
+				Check:  resource.TestCheckResourceAttr("ontap_fcp_service.test", "enabled", "false"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_fcp_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase, running
+			// services are disabled first
+			{
+				Config: testAccFCPServiceResourceConfig(true),
+				Check:  resource.TestCheckResourceAttr("ontap_fcp_service.test", "enabled", "true"),
+			},
+		},
+	})
+}
+
+func testAccFCPServiceResourceConfig(enabled bool) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_fcp_service" "test" {
+  svm     = "svm_fcp"
+  enabled = %t
+}
+`, enabled)
+}
@@ -0,0 +1,166 @@
+package ontap
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringListValues converts a list of types.String from a model to the values
+// sent to ONTAP, dropping null elements
+func stringListValues(list []types.String) []string {
+	if list == nil {
+		return nil
+	}
+
+	values := []string{}
+	for _, v := range list {
+		if !v.Null && !v.Unknown {
+			values = append(values, v.Value)
+		}
+	}
+	return values
+}
+
+// stringListModel converts values returned by ONTAP to a list of
+// types.String, a nil slice being stored as null in the state
+func stringListModel(values []string) []types.String {
+	if values == nil {
+		return nil
+	}
+
+	list := []types.String{}
+	for _, v := range values {
+		list = append(list, types.String{Value: v})
+	}
+	return list
+}
+
+// int64ListValues converts a list of types.Int64 from a model to the values
+// sent to ONTAP, dropping null elements
+func int64ListValues(list []types.Int64) []int64 {
+	if list == nil {
+		return nil
+	}
+
+	values := []int64{}
+	for _, v := range list {
+		if !v.Null && !v.Unknown {
+			values = append(values, v.Value)
+		}
+	}
+	return values
+}
+
+// int64ListModel converts values returned by ONTAP to a list of types.Int64,
+// a nil or empty slice being stored as null in the state
+func int64ListModel(values []int64) []types.Int64 {
+	if len(values) == 0 {
+		return nil
+	}
+
+	list := []types.Int64{}
+	for _, v := range values {
+		list = append(list, types.Int64{Value: v})
+	}
+	return list
+}
+
+// stringModel converts a string returned by ONTAP to a types.String, an empty
+// string being stored as null in the state
+func stringModel(value string) types.String {
+	return types.String{Null: value == "", Value: value}
+}
+
+// stringPointerModel converts an optional string returned by ONTAP to a
+// types.String, nil or empty strings being stored as null in the state
+func stringPointerModel(value *string) types.String {
+	if value == nil {
+		return types.String{Null: true}
+	}
+	return stringModel(*value)
+}
+
+// stringPointerValue converts a types.String from a model to an optional
+// string sent to ONTAP, a null value being sent as an empty string so it is
+// cleared on update
+func stringPointerValue(value types.String) *string {
+	v := value.Value
+	return &v
+}
+
+// timeModel converts a timestamp returned by ONTAP to a types.String, keeping
+// the prior value when both represent the same instant, as ONTAP reformats
+// timestamps using the cluster time zone
+func timeModel(prior types.String, value string) types.String {
+	if value == "" {
+		return types.String{Null: true}
+	}
+
+	if !prior.Null && !prior.Unknown {
+		p, perr := time.Parse(time.RFC3339, prior.Value)
+		v, verr := time.Parse(time.RFC3339, value)
+		if perr == nil && verr == nil && p.Equal(v) {
+			return prior
+		}
+	}
+
+	return types.String{Value: value}
+}
+
+// boolPointerModel converts an optional bool returned by ONTAP to a
+// types.Bool, nil being stored as null in the state
+func boolPointerModel(value *bool) types.Bool {
+	if value == nil {
+		return types.Bool{Null: true}
+	}
+	return types.Bool{Value: *value}
+}
+
+// stringListDiff returns the values of next missing from prior, and the
+// values of prior missing from next, so collections can be updated
+// incrementally
+func stringListDiff(prior []string, next []string) (added []string, removed []string) {
+	prior_set := map[string]bool{}
+	for _, v := range prior {
+		prior_set[v] = true
+	}
+	next_set := map[string]bool{}
+	for _, v := range next {
+		next_set[v] = true
+	}
+
+	for _, v := range next {
+		if !prior_set[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range prior {
+		if !next_set[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+// stringSetModel converts values returned by ONTAP to a set of types.String,
+// an empty collection being stored as null unless the prior value was an
+// empty set
+func stringSetModel(prior []types.String, values []string) []types.String {
+	if len(values) == 0 {
+		if prior == nil {
+			return nil
+		}
+		return []types.String{}
+	}
+	return stringListModel(values)
+}
+
+// int64PointerModel converts an optional int64 returned by ONTAP to a
+// types.Int64, nil being stored as null in the state
+func int64PointerModel(value *int64) types.Int64 {
+	if value == nil {
+		return types.Int64{Null: true}
+	}
+	return types.Int64{Value: *value}
+}
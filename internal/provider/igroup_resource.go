@@ -0,0 +1,379 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &IGroupResource{}
+var _ resource.ResourceWithImportState = &IGroupResource{}
+
+func NewIGroupResource() resource.Resource {
+	return &IGroupResource{}
+}
+
+// IGroupResource defines the resource implementation.
+type IGroupResource struct {
+	client *ontap.Client
+}
+
+// IGroupResourceModel describes the resource data model.
+type IGroupResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SVM        types.String   `tfsdk:"svm"`
+	Name       types.String   `tfsdk:"name"`
+	Protocol   types.String   `tfsdk:"protocol"`
+	OSType     types.String   `tfsdk:"os_type"`
+	Comment    types.String   `tfsdk:"comment"`
+	Initiators []types.String `tfsdk:"initiators"`
+	IGroups    []types.String `tfsdk:"igroups"`
+	Portset    types.String   `tfsdk:"portset"`
+}
+
+func (r *IGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_igroup"
+}
+
+func (r *IGroupResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "An initiator group, granting hosts access to LUNs",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the initiator group, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Initiator group UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the initiator group",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Initiator group name",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"protocol": {
+				MarkdownDescription: "Protocol of the initiators, `iscsi`, `fcp` or `mixed`. Defaults to `mixed`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("iscsi", "fcp", "mixed"),
+				},
+			},
+			"os_type": {
+				MarkdownDescription: "Operating system of the initiators, i.e. `linux`, `vmware` or `windows`",
+				Type:                types.StringType,
+				Required:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(lunOSTypes...),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "Initiator group comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"initiators": {
+				MarkdownDescription: "iSCSI IQNs or FC WWPNs of the initiators. Initiators are added and removed individually when the set changes",
+				Type:                types.SetType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"igroups": {
+				MarkdownDescription: "Names of the initiator groups nested in this one, mutually exclusive with `initiators`",
+				Type:                types.SetType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"portset": {
+				MarkdownDescription: "Name of the portset bound to the initiator group",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *IGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *IGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	igroup := ontap.IGroup{
+		SVM:     &ontap.UUIDRef{Name: data.SVM.Value},
+		Name:    data.Name.Value,
+		OSType:  data.OSType.Value,
+		Comment: stringPointerValue(data.Comment),
+	}
+	if !data.Protocol.Null && !data.Protocol.Unknown {
+		igroup.Protocol = data.Protocol.Value
+	}
+	for _, initiator := range stringListValues(data.Initiators) {
+		igroup.Initiators = append(igroup.Initiators, ontap.IGroupInitiator{Name: initiator})
+	}
+	for _, child := range stringListValues(data.IGroups) {
+		igroup.IGroups = append(igroup.IGroups, ontap.UUIDRef{Name: child})
+	}
+	if !data.Portset.Null {
+		igroup.Portset = &ontap.UUIDRef{Name: data.Portset.Value}
+	}
+
+	created_igroup, err := r.client.WithContext(ctx).CreateIGroup(&igroup)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create igroup, got error: %s", err))
+		return
+	}
+
+	data.fromIGroup(created_igroup)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *IGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	igroup, err := r.client.WithContext(ctx).GetIGroup(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read igroup, got error: %s", err))
+		return
+	}
+
+	data.fromIGroup(igroup)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *IGroupResourceModel
+	var state *IGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := state.UUID.Value
+
+	igroup := ontap.IGroup{
+		UUID:    uuid,
+		Name:    plan.Name.Value,
+		OSType:  plan.OSType.Value,
+		Comment: stringPointerValue(plan.Comment),
+	}
+	if !plan.Portset.Null {
+		igroup.Portset = &ontap.UUIDRef{Name: plan.Portset.Value}
+	} else if !state.Portset.Null {
+		// An empty portset name unbinds the current portset
+		igroup.Portset = &ontap.UUIDRef{}
+	}
+
+	current_igroup, err := r.client.WithContext(ctx).UpdateIGroup(&igroup)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update igroup, got error: %s", err))
+		return
+	}
+
+	err = r.updateMembers(ctx, current_igroup, state, plan)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update igroup members, got error: %s", err))
+
+		// Members changed before the error are saved, so that the next plan
+		// starts from the actual igroup
+		current_igroup, err = r.client.WithContext(ctx).GetIGroup(uuid)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read igroup, got error: %s", err))
+			return
+		}
+
+		state.fromIGroup(current_igroup)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	updated_igroup, err := r.client.WithContext(ctx).GetIGroup(uuid)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read igroup, got error: %s", err))
+		return
+	}
+
+	plan.fromIGroup(updated_igroup)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// updateMembers adds and removes the initiators and nested igroups changed
+// between state and plan. They are changed one by one so hosts that stay in
+// the group keep access to their LUNs
+func (r *IGroupResource) updateMembers(ctx context.Context, igroup *ontap.IGroup, state *IGroupResourceModel, plan *IGroupResourceModel) error {
+	added, removed := stringListDiff(stringListValues(state.Initiators), stringListValues(plan.Initiators))
+
+	for _, initiator := range added {
+		err := r.client.WithContext(ctx).AddIGroupInitiator(igroup.UUID, initiator)
+
+		if err != nil {
+			return fmt.Errorf("unable to add initiator %s: %w", initiator, err)
+		}
+	}
+
+	for _, initiator := range removed {
+		err := r.client.WithContext(ctx).RemoveIGroupInitiator(igroup.UUID, initiator)
+
+		if err != nil {
+			return fmt.Errorf("unable to remove initiator %s: %w", initiator, err)
+		}
+	}
+
+	added, removed = stringListDiff(stringListValues(state.IGroups), stringListValues(plan.IGroups))
+
+	for _, child := range added {
+		err := r.client.WithContext(ctx).AddIGroupChild(igroup.UUID, child)
+
+		if err != nil {
+			return fmt.Errorf("unable to add nested igroup %s: %w", child, err)
+		}
+	}
+
+	for _, child := range removed {
+		for _, nested := range igroup.IGroups {
+			if nested.Name != child {
+				continue
+			}
+
+			err := r.client.WithContext(ctx).RemoveIGroupChild(igroup.UUID, nested.UUID)
+
+			if err != nil {
+				return fmt.Errorf("unable to remove nested igroup %s: %w", child, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *IGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *IGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	igroup := ontap.IGroup{}
+	igroup.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteIGroup(&igroup)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete igroup, got error: %s", err))
+		return
+	}
+}
+
+func (r *IGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *IGroupResourceModel) fromIGroup(igroup *ontap.IGroup) {
+	data.UUID = types.String{Value: igroup.UUID}
+	data.ID = data.UUID
+	if igroup.SVM != nil {
+		data.SVM = types.String{Value: igroup.SVM.Name}
+	}
+	data.Name = types.String{Value: igroup.Name}
+	data.Protocol = types.String{Value: igroup.Protocol}
+	data.OSType = types.String{Value: igroup.OSType}
+	data.Comment = stringPointerModel(igroup.Comment)
+
+	initiators := []string{}
+	for _, initiator := range igroup.Initiators {
+		initiators = append(initiators, initiator.Name)
+	}
+	data.Initiators = stringSetModel(data.Initiators, initiators)
+
+	children := []string{}
+	for _, child := range igroup.IGroups {
+		children = append(children, child.Name)
+	}
+	data.IGroups = stringSetModel(data.IGroups, children)
+
+	data.Portset = types.String{Null: true}
+	if igroup.Portset != nil {
+		data.Portset = stringModel(igroup.Portset.Name)
+	}
+}
@@ -0,0 +1,155 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIGroupResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_igroup")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckIGroupDestroyed("svm_igroup", "ig_hosts", "ig_parent"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIGroupResourceConfig(`["iqn.1998-01.com.vmware:esx1", "iqn.1998-01.com.vmware:esx2"]`, "vmware"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_igroup.hosts", "uuid"),
+					resource.TestCheckResourceAttr("ontap_igroup.hosts", "protocol", "iscsi"),
+					resource.TestCheckResourceAttr("ontap_igroup.hosts", "initiators.#", "2"),
+					resource.TestCheckResourceAttr("ontap_igroup.parent", "protocol", "mixed"),
+					resource.TestCheckResourceAttr("ontap_igroup.parent", "igroups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("ontap_igroup.parent", "igroups.*", "ig_hosts"),
+					testAccCheckIGroupInitiators("svm_igroup", "ig_hosts", "iqn.1998-01.com.vmware:esx1", "iqn.1998-01.com.vmware:esx2"),
+				),
+			},
+			// Update and Read testing, initiators are changed in place
+			{
+				Config: testAccIGroupResourceConfig(`["iqn.1998-01.com.vmware:esx1", "iqn.1998-01.com.vmware:esx3"]`, "linux"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_igroup.hosts", "os_type", "linux"),
+					resource.TestCheckTypeSetElemAttr("ontap_igroup.hosts", "initiators.*", "iqn.1998-01.com.vmware:esx3"),
+					resource.TestCheckResourceAttrPair("ontap_igroup.hosts", "uuid", "ontap_igroup.hosts", "id"),
+					testAccCheckIGroupInitiators("svm_igroup", "ig_hosts", "iqn.1998-01.com.vmware:esx1", "iqn.1998-01.com.vmware:esx3"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_igroup.hosts",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "ontap_igroup.parent",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccIGroupResourcePartialUpdate(t *testing.T) {
+	testAccSimulator.AddSVM("svm_igroup_partial")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckIGroupDestroyed("svm_igroup_partial", "ig_partial", "ig_partial_other"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIGroupResourcePartialConfig(`["iqn.1998-01.com.vmware:esx1"]`, ""),
+			},
+			// ONTAP refuses to nest an igroup in an igroup with initiators,
+			// after the initiator was added
+			{
+				Config:      testAccIGroupResourcePartialConfig(`["iqn.1998-01.com.vmware:esx1", "iqn.1998-01.com.vmware:esx2"]`, `igroups = [ontap_igroup.other.name]`),
+				ExpectError: regexp.MustCompile(`unable to add nested igroup\s+ig_partial_other`),
+			},
+			// The added initiator is in the state
+			{
+				Config:   testAccIGroupResourcePartialConfig(`["iqn.1998-01.com.vmware:esx1", "iqn.1998-01.com.vmware:esx2"]`, ""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccIGroupResourceConfig(initiators string, os_type string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_igroup" "hosts" {
+  svm        = "svm_igroup"
+  name       = "ig_hosts"
+  protocol   = "iscsi"
+  os_type    = %q
+  comment    = "created by acceptance tests"
+  initiators = %s
+}
+
+resource "ontap_igroup" "parent" {
+  svm     = "svm_igroup"
+  name    = "ig_parent"
+  os_type = "vmware"
+  igroups = [ontap_igroup.hosts.name]
+}
+`, os_type, initiators)
+}
+
+func testAccIGroupResourcePartialConfig(initiators string, igroups string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_igroup" "other" {
+  svm     = "svm_igroup_partial"
+  name    = "ig_partial_other"
+  os_type = "linux"
+}
+
+resource "ontap_igroup" "test" {
+  svm        = "svm_igroup_partial"
+  name       = "ig_partial"
+  os_type    = "linux"
+  initiators = %s
+  %s
+}
+`, initiators, igroups)
+}
+
+func testAccCheckIGroupInitiators(svm_name string, name string, initiators ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		igroup := testAccSimulator.IGroup(svm_name, name)
+		if igroup == nil {
+			return fmt.Errorf("igroup %s doesn't exist", name)
+		}
+
+		members, _ := igroup["initiators"].([]interface{})
+		names := []string{}
+		for _, member := range members {
+			names = append(names, testAccRecordField(member.(map[string]interface{}), "name"))
+		}
+		sort.Strings(names)
+
+		if strings.Join(names, ",") != strings.Join(initiators, ",") {
+			return fmt.Errorf("expected igroup %s initiators %v, got %v", name, initiators, names)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIGroupDestroyed(svm_name string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.IGroup(svm_name, name) != nil {
+				return fmt.Errorf("igroup %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,332 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ISCSICredentialsResource{}
+var _ resource.ResourceWithImportState = &ISCSICredentialsResource{}
+var _ resource.ResourceWithValidateConfig = &ISCSICredentialsResource{}
+
+func NewISCSICredentialsResource() resource.Resource {
+	return &ISCSICredentialsResource{}
+}
+
+// ISCSICredentialsResource defines the resource implementation.
+type ISCSICredentialsResource struct {
+	client *ontap.Client
+}
+
+// ISCSICredentialsResourceModel describes the resource data model.
+type ISCSICredentialsResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM                types.String `tfsdk:"svm"`
+	Initiator          types.String `tfsdk:"initiator"`
+	AuthenticationType types.String `tfsdk:"authentication_type"`
+	InboundUser        types.String `tfsdk:"inbound_user"`
+	InboundPassword    types.String `tfsdk:"inbound_password"`
+	OutboundUser       types.String `tfsdk:"outbound_user"`
+	OutboundPassword   types.String `tfsdk:"outbound_password"`
+}
+
+func (r *ISCSICredentialsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iscsi_credentials"
+}
+
+func (r *ISCSICredentialsResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "iSCSI authentication of an initiator. ONTAP never returns CHAP passwords, so changes made outside of Terraform are not detected",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the credentials, `<svm_uuid>/<initiator>`",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"initiator": {
+				MarkdownDescription: "IQN of the initiator, or `default` for the authentication of initiators without credentials. The `default` credentials exist with the iSCSI service, destroying them resets their authentication to `none`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"authentication_type": {
+				MarkdownDescription: "Authentication of the initiator, `chap`, `none` or `deny`",
+				Type:                types.StringType,
+				Required:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("chap", "none", "deny"),
+				},
+			},
+			"inbound_user": {
+				MarkdownDescription: "CHAP user authenticating the initiator",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"inbound_password": {
+				MarkdownDescription: "CHAP secret authenticating the initiator",
+				Type:                types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"outbound_user": {
+				MarkdownDescription: "CHAP user authenticating the target, for mutual authentication",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"outbound_password": {
+				MarkdownDescription: "CHAP secret authenticating the target, for mutual authentication",
+				Type:                types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+		},
+	}, nil
+}
+
+func (r *ISCSICredentialsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ISCSICredentialsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ISCSICredentialsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.AuthenticationType.Unknown {
+		return
+	}
+
+	if data.AuthenticationType.Value != "chap" {
+		chap_attributes := map[string]types.String{
+			"inbound_user":      data.InboundUser,
+			"inbound_password":  data.InboundPassword,
+			"outbound_user":     data.OutboundUser,
+			"outbound_password": data.OutboundPassword,
+		}
+		for attribute, value := range chap_attributes {
+			if !value.Null {
+				resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid Attribute Combination", fmt.Sprintf("%s can only be set when authentication_type is chap", attribute))
+			}
+		}
+		return
+	}
+
+	if data.InboundUser.Null || data.InboundPassword.Null {
+		resp.Diagnostics.AddAttributeError(path.Root("inbound_user"), "Missing Attribute", "inbound_user and inbound_password are required when authentication_type is chap")
+	}
+	if data.OutboundUser.Null != data.OutboundPassword.Null {
+		resp.Diagnostics.AddAttributeError(path.Root("outbound_user"), "Missing Attribute", "outbound_user and outbound_password must be set together")
+	}
+}
+
+func (r *ISCSICredentialsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ISCSICredentialsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials := data.toISCSICredentials()
+	credentials.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+
+	created_credentials, err := r.client.WithContext(ctx).CreateISCSICredentials(&credentials)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create iscsi credentials, got error: %s", err))
+		return
+	}
+
+	data.fromISCSICredentials(created_credentials)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ISCSICredentialsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ISCSICredentialsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := r.client.WithContext(ctx).GetISCSICredentials(data.SVMUUID.Value, data.Initiator.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read iscsi credentials, got error: %s", err))
+		return
+	}
+
+	data.fromISCSICredentials(credentials)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ISCSICredentialsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ISCSICredentialsResourceModel
+	var state *ISCSICredentialsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials := plan.toISCSICredentials()
+	credentials.SVM = &ontap.UUIDRef{UUID: state.SVMUUID.Value}
+
+	updated_credentials, err := r.client.WithContext(ctx).UpdateISCSICredentials(&credentials)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update iscsi credentials, got error: %s", err))
+		return
+	}
+
+	plan.fromISCSICredentials(updated_credentials)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ISCSICredentialsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ISCSICredentialsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteISCSICredentials(data.SVMUUID.Value, data.Initiator.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete iscsi credentials, got error: %s", err))
+		return
+	}
+}
+
+func (r *ISCSICredentialsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	s := strings.SplitN(req.ID, "/", 2)
+
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <svm_uuid>/<initiator>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("svm_uuid"), s[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("initiator"), s[1])...)
+}
+
+func (data *ISCSICredentialsResourceModel) toISCSICredentials() ontap.ISCSICredentials {
+	credentials := ontap.ISCSICredentials{}
+	credentials.Initiator = data.Initiator.Value
+	credentials.AuthenticationType = data.AuthenticationType.Value
+
+	if data.AuthenticationType.Value == "chap" {
+		credentials.CHAP = &ontap.ISCSICredentialsCHAP{
+			Inbound: &ontap.ISCSICHAPUser{
+				User:     data.InboundUser.Value,
+				Password: data.InboundPassword.Value,
+			},
+		}
+		if !data.OutboundUser.Null {
+			credentials.CHAP.Outbound = &ontap.ISCSICHAPUser{
+				User:     data.OutboundUser.Value,
+				Password: data.OutboundPassword.Value,
+			}
+		}
+	}
+
+	return credentials
+}
+
+// fromISCSICredentials updates the model from ONTAP, passwords are kept from
+// the plan or prior state as they are never returned
+func (data *ISCSICredentialsResourceModel) fromISCSICredentials(credentials *ontap.ISCSICredentials) {
+	if credentials.SVM != nil {
+		data.SVMUUID = types.String{Value: credentials.SVM.UUID}
+		data.SVM = types.String{Value: credentials.SVM.Name}
+	}
+	data.Initiator = types.String{Value: credentials.Initiator}
+	data.ID = types.String{Value: fmt.Sprintf("%s/%s", data.SVMUUID.Value, data.Initiator.Value)}
+	data.AuthenticationType = types.String{Value: credentials.AuthenticationType}
+
+	data.InboundUser = types.String{Null: true}
+	data.OutboundUser = types.String{Null: true}
+	if credentials.CHAP != nil {
+		if credentials.CHAP.Inbound != nil {
+			data.InboundUser = stringModel(credentials.CHAP.Inbound.User)
+		}
+		if credentials.CHAP.Outbound != nil {
+			data.OutboundUser = stringModel(credentials.CHAP.Outbound.User)
+		}
+	}
+	if data.InboundPassword.Unknown {
+		data.InboundPassword = types.String{Null: true}
+	}
+	if data.OutboundPassword.Unknown {
+		data.OutboundPassword = types.String{Null: true}
+	}
+}
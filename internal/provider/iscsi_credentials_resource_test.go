@@ -0,0 +1,144 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccISCSICredentialsResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_iscsi_chap")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckISCSICredentialsDestroyed("svm_iscsi_chap", "default", "iqn.1994-05.com.redhat:host1"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the default credentials are updated
+			{
+				Config: testAccISCSICredentialsResourceConfig("deny", "secret-one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_iscsi_credentials.default", "authentication_type", "deny"),
+					resource.TestCheckResourceAttr("ontap_iscsi_credentials.host", "authentication_type", "chap"),
+					resource.TestCheckResourceAttr("ontap_iscsi_credentials.host", "inbound_user", "host1"),
+					resource.TestCheckResourceAttr("ontap_iscsi_credentials.host", "outbound_user", "target"),
+					testAccCheckISCSIChapPassword("svm_iscsi_chap", "iqn.1994-05.com.redhat:host1", "inbound", "secret-one"),
+					testAccCheckISCSIChapPassword("svm_iscsi_chap", "iqn.1994-05.com.redhat:host1", "outbound", "target-secret"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccISCSICredentialsResourceConfig("none", "secret-two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_iscsi_credentials.default", "authentication_type", "none"),
+					testAccCheckISCSIChapPassword("svm_iscsi_chap", "iqn.1994-05.com.redhat:host1", "inbound", "secret-two"),
+				),
+			},
+			// ImportState testing, ONTAP never returns passwords
+			{
+				ResourceName:            "ontap_iscsi_credentials.host",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"inbound_password", "outbound_password"},
+			},
+			// The default credentials are reset when destroyed
+			{
+				Config: testAccISCSICredentialsResourceServiceConfig(),
+				Check: func(s *terraform.State) error {
+					credentials := testAccSimulator.ISCSICredentials("svm_iscsi_chap", "default")
+					if credentials == nil || credentials["authentication_type"] != "none" {
+						return fmt.Errorf("expected default credentials without authentication, got %v", credentials)
+					}
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccISCSICredentialsResourceInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "ontap_iscsi_credentials" "test" {
+  svm                 = "svm_iscsi_chap"
+  initiator           = "iqn.1994-05.com.redhat:host1"
+  authentication_type = "chap"
+  inbound_user        = "host1"
+}
+`,
+				ExpectError: regexp.MustCompile("inbound_user and inbound_password are required"),
+			},
+			{
+				Config: testAccProviderConfig() + `
+resource "ontap_iscsi_credentials" "test" {
+  svm                 = "svm_iscsi_chap"
+  initiator           = "iqn.1994-05.com.redhat:host1"
+  authentication_type = "none"
+  inbound_user        = "host1"
+}
+`,
+				ExpectError: regexp.MustCompile("can only be set when authentication_type is chap"),
+			},
+		},
+	})
+}
+
+func testAccISCSICredentialsResourceServiceConfig() string {
+	return testAccProviderConfig() + `
+resource "ontap_iscsi_service" "test" {
+  svm = "svm_iscsi_chap"
+}
+`
+}
+
+func testAccISCSICredentialsResourceConfig(default_authentication string, inbound_password string) string {
+	return testAccISCSICredentialsResourceServiceConfig() + fmt.Sprintf(`
+resource "ontap_iscsi_credentials" "default" {
+  svm                 = ontap_iscsi_service.test.svm
+  initiator           = "default"
+  authentication_type = %q
+}
+
+resource "ontap_iscsi_credentials" "host" {
+  svm                 = ontap_iscsi_service.test.svm
+  initiator           = "iqn.1994-05.com.redhat:host1"
+  authentication_type = "chap"
+  inbound_user        = "host1"
+  inbound_password    = %q
+  outbound_user       = "target"
+  outbound_password   = "target-secret"
+}
+`, default_authentication, inbound_password)
+}
+
+func testAccCheckISCSIChapPassword(svm_name string, initiator string, direction string, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.ISCSIChapPassword(svm_name, initiator, direction) != password {
+			return fmt.Errorf("unexpected %s chap password for %s", direction, initiator)
+		}
+		credentials := testAccSimulator.ISCSICredentials(svm_name, initiator)
+		if testAccRecordField(credentials, "chap."+direction+".password") != "" {
+			return fmt.Errorf("chap password of %s is returned by ONTAP", initiator)
+		}
+		return nil
+	}
+}
+
+func testAccCheckISCSICredentialsDestroyed(svm_name string, initiators ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, initiator := range initiators {
+			if testAccSimulator.ISCSICredentials(svm_name, initiator) != nil {
+				return fmt.Errorf("iscsi credentials of %s still exist", initiator)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,248 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ISCSIServiceResource{}
+var _ resource.ResourceWithImportState = &ISCSIServiceResource{}
+
+func NewISCSIServiceResource() resource.Resource {
+	return &ISCSIServiceResource{}
+}
+
+// ISCSIServiceResource defines the resource implementation.
+type ISCSIServiceResource struct {
+	client *ontap.Client
+}
+
+// ISCSIServiceResourceModel describes the resource data model.
+type ISCSIServiceResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM         types.String `tfsdk:"svm"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	TargetAlias types.String `tfsdk:"target_alias"`
+	TargetName  types.String `tfsdk:"target_name"`
+}
+
+func (r *ISCSIServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iscsi_service"
+}
+
+func (r *ISCSIServiceResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The iSCSI service of an SVM. The default CHAP security of the SVM is managed with an `ontap_iscsi_credentials` resource for the `default` initiator",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the iSCSI service, same as svm_uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"enabled": {
+				MarkdownDescription: "Whether the iSCSI service is running. Defaults to `true`",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"target_alias": {
+				MarkdownDescription: "Alias of the iSCSI target, defaults to the SVM name",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"target_name": {
+				MarkdownDescription: "IQN of the iSCSI target",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *ISCSIServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ISCSIServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ISCSIServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := data.toISCSIService()
+	service.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+
+	created_service, err := r.client.WithContext(ctx).CreateISCSIService(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create iscsi service, got error: %s", err))
+		return
+	}
+
+	data.fromISCSIService(created_service)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ISCSIServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ISCSIServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.WithContext(ctx).GetISCSIService(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read iscsi service, got error: %s", err))
+		return
+	}
+
+	data.fromISCSIService(service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ISCSIServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ISCSIServiceResourceModel
+	var state *ISCSIServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := plan.toISCSIService()
+	service.SVM = &ontap.UUIDRef{UUID: state.SVMUUID.Value}
+
+	updated_service, err := r.client.WithContext(ctx).UpdateISCSIService(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update iscsi service, got error: %s", err))
+		return
+	}
+
+	plan.fromISCSIService(updated_service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ISCSIServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ISCSIServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteISCSIService(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete iscsi service, got error: %s", err))
+		return
+	}
+}
+
+func (r *ISCSIServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("svm_uuid"), req, resp)
+}
+
+func (data *ISCSIServiceResourceModel) toISCSIService() ontap.ISCSIService {
+	service := ontap.ISCSIService{}
+	if !data.Enabled.Null && !data.Enabled.Unknown {
+		service.Enabled = &data.Enabled.Value
+	}
+	if !data.TargetAlias.Null && !data.TargetAlias.Unknown {
+		service.Target = &ontap.ISCSIServiceTarget{Alias: data.TargetAlias.Value}
+	}
+
+	return service
+}
+
+func (data *ISCSIServiceResourceModel) fromISCSIService(service *ontap.ISCSIService) {
+	if service.SVM != nil {
+		data.SVMUUID = types.String{Value: service.SVM.UUID}
+		data.SVM = types.String{Value: service.SVM.Name}
+	}
+	data.ID = data.SVMUUID
+	data.Enabled = boolPointerModel(service.Enabled)
+
+	data.TargetAlias = types.String{Null: true}
+	data.TargetName = types.String{Null: true}
+	if service.Target != nil {
+		data.TargetAlias = stringModel(service.Target.Alias)
+		data.TargetName = stringModel(service.Target.Name)
+	}
+}
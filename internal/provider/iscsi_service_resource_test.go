@@ -0,0 +1,72 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccISCSIServiceResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_iscsi")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSANServiceDestroyed("iscsi", "svm_iscsi"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the target alias defaults to the SVM
+			// name
+			{
+				Config: testAccISCSIServiceResourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_iscsi_service.test", "svm_uuid"),
+					resource.TestCheckResourceAttr("ontap_iscsi_service.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("ontap_iscsi_service.test", "target_alias", "svm_iscsi"),
+					resource.TestCheckResourceAttrSet("ontap_iscsi_service.test", "target_name"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccISCSIServiceResourceConfig(`
+  enabled      = false
+  target_alias = "iscsi_alias"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_iscsi_service.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("ontap_iscsi_service.test", "target_alias", "iscsi_alias"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_iscsi_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase, running
+			// services are disabled first
+			{
+				Config: testAccISCSIServiceResourceConfig(`
+  enabled = true`),
+				Check: resource.TestCheckResourceAttr("ontap_iscsi_service.test", "enabled", "true"),
+			},
+		},
+	})
+}
+
+func testAccISCSIServiceResourceConfig(attributes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_iscsi_service" "test" {
+  svm = "svm_iscsi"%s
+}
+`, attributes)
+}
+
+func testAccCheckSANServiceDestroyed(protocol string, svm_name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.SANService(protocol, svm_name) != nil {
+			return fmt.Errorf("%s service of svm %s still exists", protocol, svm_name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &LUNDataSource{}
+
+func NewLUNDataSource() datasource.DataSource {
+	return &LUNDataSource{}
+}
+
+// LUNDataSource defines the data source implementation.
+type LUNDataSource struct {
+	client *ontap.Client
+}
+
+func (d *LUNDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lun"
+}
+
+func (d *LUNDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A LUN, looked up by `uuid` or by `svm` and `name`",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the LUN, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"uuid": {
+				MarkdownDescription: "LUN UUID",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the LUN",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": {
+				MarkdownDescription: "Path of the LUN, i.e. `/vol/vol1/lun1`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+			},
+			"os_type": {
+				MarkdownDescription: "Operating system of the LUN initiators",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"size": {
+				MarkdownDescription: "Size of the LUN in bytes",
+				Type:                types.Int64Type,
+				Computed:            true,
+			},
+			"space_reservation": {
+				MarkdownDescription: "Whether space is reserved for the LUN in its volume",
+				Type:                types.BoolType,
+				Computed:            true,
+			},
+			"qos_policy": {
+				MarkdownDescription: "Name of the QoS policy applied to the LUN",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"comment": {
+				MarkdownDescription: "LUN comment",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"serial_number": {
+				MarkdownDescription: "Serial number of the LUN",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"mapped": {
+				MarkdownDescription: "Whether the LUN is mapped to an initiator group",
+				Type:                types.BoolType,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (d *LUNDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LUNDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LUNResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var lun *ontap.LUN
+	var err error
+
+	if !data.UUID.Null {
+		lun, err = d.client.WithContext(ctx).GetLUN(data.UUID.Value)
+	} else if !data.SVM.Null && !data.Name.Null {
+		lun, err = d.client.WithContext(ctx).GetLUNByPath(data.SVM.Value, data.Name.Value)
+	} else {
+		resp.Diagnostics.AddError("Missing Attribute", "Either uuid, or svm and name must be set")
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lun, got error: %s", err))
+		return
+	}
+
+	data.fromLUN(lun)
+
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
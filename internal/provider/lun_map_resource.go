@@ -0,0 +1,338 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &LUNMapResource{}
+var _ resource.ResourceWithImportState = &LUNMapResource{}
+
+func NewLUNMapResource() resource.Resource {
+	return &LUNMapResource{}
+}
+
+// LUNMapResource defines the resource implementation.
+type LUNMapResource struct {
+	client *ontap.Client
+}
+
+// LUNMapResourceModel describes the resource data model.
+type LUNMapResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	LUNUUID    types.String `tfsdk:"lun_uuid"`
+	IGroupUUID types.String `tfsdk:"igroup_uuid"`
+
+	SVM               types.String   `tfsdk:"svm"`
+	LUN               types.String   `tfsdk:"lun"`
+	IGroup            types.String   `tfsdk:"igroup"`
+	LogicalUnitNumber types.Int64    `tfsdk:"logical_unit_number"`
+	ReportingNodes    []types.String `tfsdk:"reporting_nodes"`
+}
+
+func (r *LUNMapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lun_map"
+}
+
+func (r *LUNMapResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A LUN mapped to an initiator group",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the LUN map, `<lun_uuid>/<igroup_uuid>`",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"lun_uuid": {
+				MarkdownDescription: "UUID of the mapped LUN",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"igroup_uuid": {
+				MarkdownDescription: "UUID of the initiator group",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the LUN and the initiator group",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"lun": {
+				MarkdownDescription: "Path of the LUN, i.e. `/vol/vol1/lun1`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"igroup": {
+				MarkdownDescription: "Name of the initiator group",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"logical_unit_number": {
+				MarkdownDescription: "Logical unit number presented to the initiators, assigned by ONTAP when omitted",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"reporting_nodes": {
+				MarkdownDescription: "Names of the nodes reporting the LUN to the initiators. ONTAP always reports a LUN through both nodes of an HA pair, so both partners should be listed. Defaults to the HA pair owning the LUN",
+				Type:                types.SetType{ElemType: types.StringType},
+				Optional:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *LUNMapResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *LUNMapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *LUNMapResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun_map := ontap.LUNMap{
+		SVM:    &ontap.UUIDRef{Name: data.SVM.Value},
+		LUN:    &ontap.UUIDRef{Name: data.LUN.Value},
+		IGroup: &ontap.UUIDRef{Name: data.IGroup.Value},
+	}
+	if !data.LogicalUnitNumber.Null && !data.LogicalUnitNumber.Unknown {
+		lun_map.LogicalUnitNumber = &data.LogicalUnitNumber.Value
+	}
+
+	created_map, err := r.client.WithContext(ctx).CreateLUNMap(&lun_map)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create lun map, got error: %s", err))
+		return
+	}
+
+	if data.ReportingNodes != nil {
+		created_map, err = r.updateReportingNodes(ctx, created_map, stringListValues(data.ReportingNodes))
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lun map reporting nodes, got error: %s", err))
+			return
+		}
+	}
+
+	data.fromLUNMap(created_map)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LUNMapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LUNMapResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun_map, err := r.client.WithContext(ctx).GetLUNMap(data.LUNUUID.Value, data.IGroupUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lun map, got error: %s", err))
+		return
+	}
+
+	data.fromLUNMap(lun_map)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LUNMapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *LUNMapResourceModel
+	var state *LUNMapResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun_map, err := r.client.WithContext(ctx).GetLUNMap(state.LUNUUID.Value, state.IGroupUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lun map, got error: %s", err))
+		return
+	}
+
+	// Reporting nodes are the only attribute updated in place, leaving them
+	// out of the configuration keeps the current nodes
+	if plan.ReportingNodes != nil {
+		lun_map, err = r.updateReportingNodes(ctx, lun_map, stringListValues(plan.ReportingNodes))
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lun map reporting nodes, got error: %s", err))
+			return
+		}
+	}
+
+	plan.fromLUNMap(lun_map)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *LUNMapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *LUNMapResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteLUNMap(data.LUNUUID.Value, data.IGroupUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete lun map, got error: %s", err))
+		return
+	}
+}
+
+func (r *LUNMapResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	s := strings.Split(req.ID, "/")
+
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <lun_uuid>/<igroup_uuid>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("lun_uuid"), s[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("igroup_uuid"), s[1])...)
+}
+
+// updateReportingNodes adds and removes reporting nodes of lun_map one by one
+// until they match nodes, and returns the updated map
+func (r *LUNMapResource) updateReportingNodes(ctx context.Context, lun_map *ontap.LUNMap, nodes []string) (*ontap.LUNMap, error) {
+	lun_uuid := lun_map.LUN.UUID
+	igroup_uuid := lun_map.IGroup.UUID
+
+	current_nodes := []string{}
+	for _, node := range lun_map.ReportingNodes {
+		current_nodes = append(current_nodes, node.Name)
+	}
+
+	added, removed := stringListDiff(current_nodes, nodes)
+
+	for _, node := range added {
+		err := r.client.WithContext(ctx).AddLUNMapReportingNode(lun_uuid, igroup_uuid, node)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, node := range removed {
+		for _, current_node := range lun_map.ReportingNodes {
+			if current_node.Name != node {
+				continue
+			}
+
+			err := r.client.WithContext(ctx).RemoveLUNMapReportingNode(lun_uuid, igroup_uuid, current_node.UUID)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r.client.WithContext(ctx).GetLUNMap(lun_uuid, igroup_uuid)
+}
+
+func (data *LUNMapResourceModel) fromLUNMap(lun_map *ontap.LUNMap) {
+	if lun_map.SVM != nil {
+		data.SVM = types.String{Value: lun_map.SVM.Name}
+	}
+	if lun_map.LUN != nil {
+		data.LUNUUID = types.String{Value: lun_map.LUN.UUID}
+		data.LUN = types.String{Value: lun_map.LUN.Name}
+	}
+	if lun_map.IGroup != nil {
+		data.IGroupUUID = types.String{Value: lun_map.IGroup.UUID}
+		data.IGroup = types.String{Value: lun_map.IGroup.Name}
+	}
+	data.ID = types.String{Value: fmt.Sprintf("%s/%s", data.LUNUUID.Value, data.IGroupUUID.Value)}
+	if lun_map.LogicalUnitNumber != nil {
+		data.LogicalUnitNumber = types.Int64{Value: *lun_map.LogicalUnitNumber}
+	} else if data.LogicalUnitNumber.Unknown {
+		data.LogicalUnitNumber = types.Int64{Null: true}
+	}
+
+	// Reporting nodes default to the HA pair owning the LUN, they are only
+	// tracked when configured
+	if data.ReportingNodes != nil {
+		nodes := []string{}
+		for _, node := range lun_map.ReportingNodes {
+			nodes = append(nodes, node.Name)
+		}
+		data.ReportingNodes = stringSetModel(data.ReportingNodes, nodes)
+	}
+}
@@ -0,0 +1,123 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLUNMapResource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_lun_map")
+	testAccSimulator.AddVolume(svm_uuid, "vol_lun_map")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckLUNMapDestroyed("svm_lun_map", "/vol/vol_lun_map/lun_map", "ig_lun_map_esx"),
+			testAccCheckLUNMapDestroyed("svm_lun_map", "/vol/vol_lun_map/lun_map", "ig_lun_map_linux"),
+		),
+		Steps: []resource.TestStep{
+			// Create and Read testing, reporting nodes default to the HA pair
+			// owning the LUN
+			{
+				Config: testAccLUNMapResourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ontap_lun_map.esx", "lun_uuid", "ontap_lun.test", "uuid"),
+					resource.TestCheckResourceAttrPair("ontap_lun_map.esx", "igroup_uuid", "ontap_igroup.esx", "uuid"),
+					resource.TestCheckResourceAttr("ontap_lun_map.esx", "logical_unit_number", "0"),
+					resource.TestCheckNoResourceAttr("ontap_lun_map.esx", "reporting_nodes"),
+					resource.TestCheckResourceAttr("ontap_lun_map.linux", "logical_unit_number", "5"),
+					testAccCheckLUNMapReportingNodes("svm_lun_map", "/vol/vol_lun_map/lun_map", "ig_lun_map_esx", 2),
+				),
+			},
+			// Update and Read testing, nodes are added by HA pair
+			{
+				Config: testAccLUNMapResourceConfig(`reporting_nodes = ["simulator-01", "simulator-02", "simulator-03", "simulator-04"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_lun_map.esx", "reporting_nodes.#", "4"),
+					resource.TestCheckResourceAttr("ontap_lun.test", "mapped", "true"),
+					testAccCheckLUNMapReportingNodes("svm_lun_map", "/vol/vol_lun_map/lun_map", "ig_lun_map_esx", 4),
+				),
+			},
+			{
+				Config: testAccLUNMapResourceConfig(`reporting_nodes = ["simulator-01", "simulator-02"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_lun_map.esx", "reporting_nodes.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ontap_lun_map.esx", "reporting_nodes.*", "simulator-02"),
+					testAccCheckLUNMapReportingNodes("svm_lun_map", "/vol/vol_lun_map/lun_map", "ig_lun_map_esx", 2),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_lun_map.linux",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLUNMapResourceConfig(reporting_nodes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_lun" "test" {
+  svm     = "svm_lun_map"
+  name    = "/vol/vol_lun_map/lun_map"
+  os_type = "vmware"
+  size    = 1048576
+}
+
+resource "ontap_igroup" "esx" {
+  svm        = "svm_lun_map"
+  name       = "ig_lun_map_esx"
+  os_type    = "vmware"
+  initiators = ["iqn.1998-01.com.vmware:esx1"]
+}
+
+resource "ontap_igroup" "linux" {
+  svm        = "svm_lun_map"
+  name       = "ig_lun_map_linux"
+  os_type    = "linux"
+  initiators = ["iqn.1994-05.com.redhat:host1"]
+}
+
+resource "ontap_lun_map" "esx" {
+  svm    = "svm_lun_map"
+  lun    = ontap_lun.test.name
+  igroup = ontap_igroup.esx.name
+  %s
+}
+
+resource "ontap_lun_map" "linux" {
+  svm                 = "svm_lun_map"
+  lun                 = ontap_lun.test.name
+  igroup              = ontap_igroup.linux.name
+  logical_unit_number = 5
+}
+`, reporting_nodes)
+}
+
+func testAccCheckLUNMapReportingNodes(svm_name string, path string, igroup_name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		lun_map := testAccSimulator.LUNMap(svm_name, path, igroup_name)
+		if lun_map == nil {
+			return fmt.Errorf("lun %s isn't mapped to %s", path, igroup_name)
+		}
+		if nodes, _ := lun_map["reporting_nodes"].([]interface{}); len(nodes) != count {
+			return fmt.Errorf("expected %d reporting nodes for lun %s in %s, got %d", count, path, igroup_name, len(nodes))
+		}
+		return nil
+	}
+}
+
+func testAccCheckLUNMapDestroyed(svm_name string, path string, igroup_name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.LUNMap(svm_name, path, igroup_name) != nil {
+			return fmt.Errorf("lun %s is still mapped to %s", path, igroup_name)
+		}
+		return nil
+	}
+}
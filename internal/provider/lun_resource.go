@@ -0,0 +1,333 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &LUNResource{}
+var _ resource.ResourceWithImportState = &LUNResource{}
+var _ resource.ResourceWithModifyPlan = &LUNResource{}
+
+func NewLUNResource() resource.Resource {
+	return &LUNResource{}
+}
+
+// LUNResource defines the resource implementation.
+type LUNResource struct {
+	client *ontap.Client
+}
+
+// LUNResourceModel describes the resource data model.
+type LUNResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SVM              types.String `tfsdk:"svm"`
+	Name             types.String `tfsdk:"name"`
+	OSType           types.String `tfsdk:"os_type"`
+	Size             types.Int64  `tfsdk:"size"`
+	SpaceReservation types.Bool   `tfsdk:"space_reservation"`
+	QOSPolicy        types.String `tfsdk:"qos_policy"`
+	Comment          types.String `tfsdk:"comment"`
+	SerialNumber     types.String `tfsdk:"serial_number"`
+	Mapped           types.Bool   `tfsdk:"mapped"`
+}
+
+// OS types accepted by ONTAP for LUNs, igroups and NVMe namespaces
+var lunOSTypes = []string{
+	"aix", "hpux", "hyper_v", "linux", "netware", "openvms", "solaris",
+	"solaris_efi", "vmware", "windows", "windows_2008", "windows_gpt", "xen",
+}
+
+func (r *LUNResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lun"
+}
+
+func (r *LUNResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A LUN",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the LUN, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "LUN UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the LUN",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Path of the LUN, i.e. `/vol/vol1/lun1`. Changing it moves the LUN",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"os_type": {
+				MarkdownDescription: "Operating system of the LUN initiators, i.e. `linux`, `vmware` or `windows`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(lunOSTypes...),
+				},
+			},
+			"size": {
+				MarkdownDescription: "Size of the LUN in bytes, changing it resizes the LUN in place",
+				Type:                types.Int64Type,
+				Required:            true,
+			},
+			"space_reservation": {
+				MarkdownDescription: "Reserve space for the LUN in its volume",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"qos_policy": {
+				MarkdownDescription: "Name of the QoS policy applied to the LUN",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"comment": {
+				MarkdownDescription: "LUN comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"serial_number": {
+				MarkdownDescription: "Serial number of the LUN",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"mapped": {
+				MarkdownDescription: "Whether the LUN is mapped to an initiator group",
+				Type:                types.BoolType,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *LUNResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Attributes of ontap_lun that require a recent ONTAP release
+var lunAttributeVersions = []attributeVersion{
+	{Path: path.Root("qos_policy"), Generation: 9, Major: 10, Minor: 1},
+}
+
+func (r *LUNResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkAttributeVersions(ctx, r.client, req.Config, lunAttributeVersions)...)
+}
+
+func (r *LUNResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *LUNResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun := data.toLUN()
+	lun.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+	lun.OSType = data.OSType.Value
+
+	created_lun, err := r.client.WithContext(ctx).CreateLUN(&lun)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create lun, got error: %s", err))
+		return
+	}
+
+	data.fromLUN(created_lun)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LUNResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *LUNResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun, err := r.client.WithContext(ctx).GetLUN(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read lun, got error: %s", err))
+		return
+	}
+
+	data.fromLUN(lun)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LUNResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *LUNResourceModel
+	var state *LUNResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun := plan.toLUN()
+	lun.UUID = state.UUID.Value
+
+	// Removing the policy from the configuration detaches it from the LUN
+	if plan.QOSPolicy.Null && !state.QOSPolicy.Null {
+		lun.QOSPolicy = &ontap.UUIDRef{Name: "none"}
+	}
+
+	updated_lun, err := r.client.WithContext(ctx).UpdateLUN(&lun)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update lun, got error: %s", err))
+		return
+	}
+
+	plan.fromLUN(updated_lun)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *LUNResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *LUNResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lun := ontap.LUN{}
+	lun.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteLUN(&lun)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete lun, got error: %s", err))
+		return
+	}
+}
+
+func (r *LUNResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+// toLUN returns the modifiable properties of the LUN
+func (data *LUNResourceModel) toLUN() ontap.LUN {
+	lun := ontap.LUN{}
+	lun.Name = data.Name.Value
+	lun.Space = &ontap.LUNSpace{
+		Size: data.Size.Value,
+	}
+	if !data.SpaceReservation.Null && !data.SpaceReservation.Unknown {
+		lun.Space.Guarantee = &ontap.LUNSpaceGuarantee{
+			Requested: &data.SpaceReservation.Value,
+		}
+	}
+	if !data.QOSPolicy.Null {
+		lun.QOSPolicy = &ontap.UUIDRef{Name: data.QOSPolicy.Value}
+	}
+	lun.Comment = stringPointerValue(data.Comment)
+
+	return lun
+}
+
+func (data *LUNResourceModel) fromLUN(lun *ontap.LUN) {
+	data.UUID = types.String{Value: lun.UUID}
+	data.ID = data.UUID
+	if lun.SVM != nil {
+		data.SVM = types.String{Value: lun.SVM.Name}
+	}
+	data.Name = types.String{Value: lun.Name}
+	data.OSType = types.String{Value: lun.OSType}
+
+	data.SpaceReservation = types.Bool{Null: true}
+	if lun.Space != nil {
+		data.Size = types.Int64{Value: lun.Space.Size}
+		if lun.Space.Guarantee != nil {
+			data.SpaceReservation = boolPointerModel(lun.Space.Guarantee.Requested)
+		}
+	}
+
+	data.QOSPolicy = types.String{Null: true}
+	if lun.QOSPolicy != nil {
+		data.QOSPolicy = stringModel(lun.QOSPolicy.Name)
+	}
+
+	data.Comment = stringPointerModel(lun.Comment)
+	data.SerialNumber = types.String{Value: lun.SerialNumber}
+
+	data.Mapped = types.Bool{Value: false}
+	if lun.Status != nil {
+		data.Mapped = types.Bool{Value: lun.Status.Mapped}
+	}
+}
@@ -0,0 +1,110 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLUNResource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_lun")
+	testAccSimulator.AddVolume(svm_uuid, "vol_lun")
+
+	var uuid string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckLUNDestroyed("svm_lun", "/vol/vol_lun/lun_acc", "/vol/vol_lun/lun_moved"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccLUNResourceConfig("/vol/vol_lun/lun_acc", 1048576, `qos_policy = "qos_lun"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_lun.test", "uuid"),
+					resource.TestCheckResourceAttrSet("ontap_lun.test", "serial_number"),
+					resource.TestCheckResourceAttr("ontap_lun.test", "size", "1048576"),
+					resource.TestCheckResourceAttr("ontap_lun.test", "space_reservation", "true"),
+					resource.TestCheckResourceAttr("ontap_lun.test", "qos_policy", "qos_lun"),
+					resource.TestCheckResourceAttr("ontap_lun.test", "mapped", "false"),
+					resource.TestCheckResourceAttrPair("data.ontap_lun.by_path", "uuid", "ontap_lun.test", "uuid"),
+					resource.TestCheckResourceAttrPair("data.ontap_lun.by_uuid", "name", "ontap_lun.test", "name"),
+					resource.TestCheckResourceAttr("data.ontap_lun.by_uuid", "os_type", "linux"),
+					resource.TestCheckResourceAttr("data.ontap_lun.by_uuid", "qos_policy", "qos_lun"),
+					testAccCheckLUNUUID("svm_lun", "/vol/vol_lun/lun_acc", &uuid),
+				),
+			},
+			// Update and Read testing, the LUN is resized and moved in place
+			{
+				Config: testAccLUNResourceConfig("/vol/vol_lun/lun_moved", 2097152, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_lun.test", "name", "/vol/vol_lun/lun_moved"),
+					resource.TestCheckResourceAttr("ontap_lun.test", "size", "2097152"),
+					resource.TestCheckNoResourceAttr("ontap_lun.test", "qos_policy"),
+					resource.TestCheckResourceAttr("data.ontap_lun.by_path", "size", "2097152"),
+					testAccCheckLUNUUID("svm_lun", "/vol/vol_lun/lun_moved", &uuid),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_lun.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccLUNResourceConfig(name string, size int64, qos_policy string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_lun" "test" {
+  svm               = "svm_lun"
+  name              = %q
+  os_type           = "linux"
+  size              = %d
+  space_reservation = true
+  comment           = "created by acceptance tests"
+  %s
+}
+
+data "ontap_lun" "by_path" {
+  svm  = ontap_lun.test.svm
+  name = ontap_lun.test.name
+}
+
+data "ontap_lun" "by_uuid" {
+  uuid = ontap_lun.test.uuid
+}
+`, name, size, qos_policy)
+}
+
+// testAccCheckLUNUUID checks that the LUN at path exists, and that it has the
+// UUID stored in uuid when set
+func testAccCheckLUNUUID(svm_name string, path string, uuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		lun := testAccSimulator.LUN(svm_name, path)
+		if lun == nil {
+			return fmt.Errorf("lun %s doesn't exist", path)
+		}
+		if *uuid == "" {
+			*uuid = testAccRecordField(lun, "uuid")
+		} else if testAccRecordField(lun, "uuid") != *uuid {
+			return fmt.Errorf("lun %s was replaced", path)
+		}
+		return nil
+	}
+}
+
+func testAccCheckLUNDestroyed(svm_name string, paths ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, path := range paths {
+			if testAccSimulator.LUN(svm_name, path) != nil {
+				return fmt.Errorf("lun %s still exists", path)
+			}
+		}
+		return nil
+	}
+}
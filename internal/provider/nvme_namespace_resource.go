@@ -0,0 +1,284 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &NVMENamespaceResource{}
+var _ resource.ResourceWithImportState = &NVMENamespaceResource{}
+
+func NewNVMENamespaceResource() resource.Resource {
+	return &NVMENamespaceResource{}
+}
+
+// NVMENamespaceResource defines the resource implementation.
+type NVMENamespaceResource struct {
+	client *ontap.Client
+}
+
+// NVMENamespaceResourceModel describes the resource data model.
+type NVMENamespaceResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SVM       types.String `tfsdk:"svm"`
+	Name      types.String `tfsdk:"name"`
+	OSType    types.String `tfsdk:"os_type"`
+	Size      types.Int64  `tfsdk:"size"`
+	BlockSize types.Int64  `tfsdk:"block_size"`
+	Comment   types.String `tfsdk:"comment"`
+	Mapped    types.Bool   `tfsdk:"mapped"`
+}
+
+// OS types accepted by ONTAP for NVMe namespaces and subsystems
+var nvmeOSTypes = []string{"aix", "linux", "vmware", "windows"}
+
+func (r *NVMENamespaceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nvme_namespace"
+}
+
+func (r *NVMENamespaceResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "An NVMe namespace, mapped to hosts through an `ontap_nvme_subsystem`",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the namespace, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Namespace UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the namespace",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Path of the namespace, i.e. `/vol/vol1/ns1`. Changing it moves the namespace",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"os_type": {
+				MarkdownDescription: "Operating system of the hosts, `aix`, `linux`, `vmware` or `windows`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(nvmeOSTypes...),
+				},
+			},
+			"size": {
+				MarkdownDescription: "Size of the namespace in bytes, changing it resizes the namespace in place",
+				Type:                types.Int64Type,
+				Required:            true,
+			},
+			"block_size": {
+				MarkdownDescription: "Block size of the namespace in bytes, `512` or `4096`. Defaults to `4096`",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "Namespace comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"mapped": {
+				MarkdownDescription: "Whether the namespace is mapped to a subsystem",
+				Type:                types.BoolType,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *NVMENamespaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NVMENamespaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *NVMENamespaceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := data.toNVMENamespace()
+	namespace.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+	namespace.OSType = data.OSType.Value
+	if !data.BlockSize.Null && !data.BlockSize.Unknown {
+		namespace.Space.BlockSize = data.BlockSize.Value
+	}
+
+	created_namespace, err := r.client.WithContext(ctx).CreateNVMENamespace(&namespace)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create nvme namespace, got error: %s", err))
+		return
+	}
+
+	data.fromNVMENamespace(created_namespace)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NVMENamespaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *NVMENamespaceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace, err := r.client.WithContext(ctx).GetNVMENamespace(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme namespace, got error: %s", err))
+		return
+	}
+
+	data.fromNVMENamespace(namespace)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NVMENamespaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *NVMENamespaceResourceModel
+	var state *NVMENamespaceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := plan.toNVMENamespace()
+	namespace.UUID = state.UUID.Value
+
+	updated_namespace, err := r.client.WithContext(ctx).UpdateNVMENamespace(&namespace)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update nvme namespace, got error: %s", err))
+		return
+	}
+
+	plan.fromNVMENamespace(updated_namespace)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NVMENamespaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *NVMENamespaceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := ontap.NVMENamespace{}
+	namespace.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteNVMENamespace(&namespace)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete nvme namespace, got error: %s", err))
+		return
+	}
+}
+
+func (r *NVMENamespaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+// toNVMENamespace returns the modifiable properties of the namespace
+func (data *NVMENamespaceResourceModel) toNVMENamespace() ontap.NVMENamespace {
+	namespace := ontap.NVMENamespace{}
+	namespace.Name = data.Name.Value
+	namespace.Space = &ontap.NVMENamespaceSpace{
+		Size: data.Size.Value,
+	}
+	namespace.Comment = stringPointerValue(data.Comment)
+
+	return namespace
+}
+
+func (data *NVMENamespaceResourceModel) fromNVMENamespace(namespace *ontap.NVMENamespace) {
+	data.UUID = types.String{Value: namespace.UUID}
+	data.ID = data.UUID
+	if namespace.SVM != nil {
+		data.SVM = types.String{Value: namespace.SVM.Name}
+	}
+	data.Name = types.String{Value: namespace.Name}
+	data.OSType = types.String{Value: namespace.OSType}
+
+	if namespace.Space != nil {
+		data.Size = types.Int64{Value: namespace.Space.Size}
+		data.BlockSize = types.Int64{Value: namespace.Space.BlockSize}
+	} else if data.BlockSize.Unknown {
+		data.BlockSize = types.Int64{Null: true}
+	}
+
+	data.Comment = stringPointerModel(namespace.Comment)
+
+	data.Mapped = types.Bool{Value: false}
+	if namespace.Status != nil {
+		data.Mapped = types.Bool{Value: namespace.Status.Mapped}
+	}
+}
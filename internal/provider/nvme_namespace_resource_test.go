@@ -0,0 +1,92 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNVMENamespaceResource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_nvme_namespace")
+	testAccSimulator.AddVolume(svm_uuid, "vol_nvme_namespace")
+
+	var uuid string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckNVMENamespaceDestroyed("svm_nvme_namespace", "/vol/vol_nvme_namespace/ns_acc", "/vol/vol_nvme_namespace/ns_moved"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the block size defaults to 4096
+			{
+				Config: testAccNVMENamespaceResourceConfig("/vol/vol_nvme_namespace/ns_acc", 1073741824),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_nvme_namespace.test", "uuid"),
+					resource.TestCheckResourceAttr("ontap_nvme_namespace.test", "size", "1073741824"),
+					resource.TestCheckResourceAttr("ontap_nvme_namespace.test", "block_size", "4096"),
+					resource.TestCheckResourceAttr("ontap_nvme_namespace.test", "mapped", "false"),
+					testAccCheckNVMENamespaceUUID("svm_nvme_namespace", "/vol/vol_nvme_namespace/ns_acc", &uuid),
+				),
+			},
+			// Update and Read testing, the namespace is resized and moved in
+			// place
+			{
+				Config: testAccNVMENamespaceResourceConfig("/vol/vol_nvme_namespace/ns_moved", 2147483648),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_nvme_namespace.test", "name", "/vol/vol_nvme_namespace/ns_moved"),
+					resource.TestCheckResourceAttr("ontap_nvme_namespace.test", "size", "2147483648"),
+					testAccCheckNVMENamespaceUUID("svm_nvme_namespace", "/vol/vol_nvme_namespace/ns_moved", &uuid),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_nvme_namespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccNVMENamespaceResourceConfig(name string, size int64) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_nvme_namespace" "test" {
+  svm     = "svm_nvme_namespace"
+  name    = %q
+  os_type = "linux"
+  size    = %d
+  comment = "created by acceptance tests"
+}
+`, name, size)
+}
+
+// testAccCheckNVMENamespaceUUID checks that the namespace at path exists,
+// and that it has the UUID stored in uuid when set
+func testAccCheckNVMENamespaceUUID(svm_name string, path string, uuid *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		namespace := testAccSimulator.NVMENamespace(svm_name, path)
+		if namespace == nil {
+			return fmt.Errorf("namespace %s doesn't exist", path)
+		}
+		if *uuid == "" {
+			*uuid = testAccRecordField(namespace, "uuid")
+		} else if testAccRecordField(namespace, "uuid") != *uuid {
+			return fmt.Errorf("namespace %s was replaced", path)
+		}
+		return nil
+	}
+}
+
+func testAccCheckNVMENamespaceDestroyed(svm_name string, paths ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, path := range paths {
+			if testAccSimulator.NVMENamespace(svm_name, path) != nil {
+				return fmt.Errorf("namespace %s still exists", path)
+			}
+		}
+		return nil
+	}
+}
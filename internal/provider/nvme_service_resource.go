@@ -0,0 +1,219 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &NVMEServiceResource{}
+var _ resource.ResourceWithImportState = &NVMEServiceResource{}
+
+func NewNVMEServiceResource() resource.Resource {
+	return &NVMEServiceResource{}
+}
+
+// NVMEServiceResource defines the resource implementation.
+type NVMEServiceResource struct {
+	client *ontap.Client
+}
+
+// NVMEServiceResourceModel describes the resource data model.
+type NVMEServiceResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM     types.String `tfsdk:"svm"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *NVMEServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nvme_service"
+}
+
+func (r *NVMEServiceResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The NVMe service of an SVM",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the NVMe service, same as svm_uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"enabled": {
+				MarkdownDescription: "Whether the NVMe service is running. Defaults to `true`",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *NVMEServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NVMEServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *NVMEServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := data.toNVMEService()
+	service.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+
+	created_service, err := r.client.WithContext(ctx).CreateNVMEService(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create nvme service, got error: %s", err))
+		return
+	}
+
+	data.fromNVMEService(created_service)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NVMEServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *NVMEServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.WithContext(ctx).GetNVMEService(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme service, got error: %s", err))
+		return
+	}
+
+	data.fromNVMEService(service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NVMEServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *NVMEServiceResourceModel
+	var state *NVMEServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := plan.toNVMEService()
+	service.SVM = &ontap.UUIDRef{UUID: state.SVMUUID.Value}
+
+	updated_service, err := r.client.WithContext(ctx).UpdateNVMEService(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update nvme service, got error: %s", err))
+		return
+	}
+
+	plan.fromNVMEService(updated_service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NVMEServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *NVMEServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteNVMEService(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete nvme service, got error: %s", err))
+		return
+	}
+}
+
+func (r *NVMEServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("svm_uuid"), req, resp)
+}
+
+func (data *NVMEServiceResourceModel) toNVMEService() ontap.NVMEService {
+	service := ontap.NVMEService{}
+	if !data.Enabled.Null && !data.Enabled.Unknown {
+		service.Enabled = &data.Enabled.Value
+	}
+
+	return service
+}
+
+func (data *NVMEServiceResourceModel) fromNVMEService(service *ontap.NVMEService) {
+	if service.SVM != nil {
+		data.SVMUUID = types.String{Value: service.SVM.UUID}
+		data.SVM = types.String{Value: service.SVM.Name}
+	}
+	data.ID = data.SVMUUID
+	data.Enabled = boolPointerModel(service.Enabled)
+}
@@ -0,0 +1,54 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNVMEServiceResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_nvme_service")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSANServiceDestroyed("nvme", "svm_nvme_service"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNVMEServiceResourceConfig(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_nvme_service.test", "svm_uuid"),
+					resource.TestCheckResourceAttr("ontap_nvme_service.test", "enabled", "true"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccNVMEServiceResourceConfig(false),
+				Check:  resource.TestCheckResourceAttr("ontap_nvme_service.test", "enabled", "false"),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_nvme_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase, running
+			// services are disabled first
+			{
+				Config: testAccNVMEServiceResourceConfig(true),
+				Check:  resource.TestCheckResourceAttr("ontap_nvme_service.test", "enabled", "true"),
+			},
+		},
+	})
+}
+
+func testAccNVMEServiceResourceConfig(enabled bool) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_nvme_service" "test" {
+  svm     = "svm_nvme_service"
+  enabled = %t
+}
+`, enabled)
+}
@@ -0,0 +1,401 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &NVMESubsystemResource{}
+var _ resource.ResourceWithImportState = &NVMESubsystemResource{}
+
+func NewNVMESubsystemResource() resource.Resource {
+	return &NVMESubsystemResource{}
+}
+
+// NVMESubsystemResource defines the resource implementation.
+type NVMESubsystemResource struct {
+	client *ontap.Client
+}
+
+// NVMESubsystemResourceModel describes the resource data model.
+type NVMESubsystemResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SVM          types.String   `tfsdk:"svm"`
+	Name         types.String   `tfsdk:"name"`
+	OSType       types.String   `tfsdk:"os_type"`
+	Comment      types.String   `tfsdk:"comment"`
+	Hosts        []types.String `tfsdk:"hosts"`
+	Namespaces   []types.String `tfsdk:"namespaces"`
+	TargetNQN    types.String   `tfsdk:"target_nqn"`
+	SerialNumber types.String   `tfsdk:"serial_number"`
+}
+
+func (r *NVMESubsystemResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nvme_subsystem"
+}
+
+func (r *NVMESubsystemResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "An NVMe subsystem, granting hosts access to namespaces",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the subsystem, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Subsystem UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the subsystem",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Subsystem name",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"os_type": {
+				MarkdownDescription: "Operating system of the hosts, `aix`, `linux`, `vmware` or `windows`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf(nvmeOSTypes...),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "Subsystem comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"hosts": {
+				MarkdownDescription: "NQNs of the hosts allowed to access the subsystem. Hosts are added and removed individually when the set changes",
+				Type:                types.SetType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"namespaces": {
+				MarkdownDescription: "Paths of the namespaces mapped to the subsystem, i.e. `/vol/vol1/ns1`",
+				Type:                types.SetType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"target_nqn": {
+				MarkdownDescription: "NQN of the subsystem, used by hosts to connect",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"serial_number": {
+				MarkdownDescription: "Serial number of the subsystem",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *NVMESubsystemResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NVMESubsystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *NVMESubsystemResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subsystem := ontap.NVMESubsystem{
+		SVM:     &ontap.UUIDRef{Name: data.SVM.Value},
+		Name:    data.Name.Value,
+		OSType:  data.OSType.Value,
+		Comment: stringPointerValue(data.Comment),
+	}
+	for _, host := range stringListValues(data.Hosts) {
+		subsystem.Hosts = append(subsystem.Hosts, ontap.NVMESubsystemHost{NQN: host})
+	}
+
+	created_subsystem, err := r.client.WithContext(ctx).CreateNVMESubsystem(&subsystem)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create nvme subsystem, got error: %s", err))
+		return
+	}
+
+	for _, namespace := range stringListValues(data.Namespaces) {
+		err = r.client.WithContext(ctx).CreateNVMESubsystemMap(&ontap.NVMESubsystemMap{
+			SVM:       &ontap.UUIDRef{Name: data.SVM.Value},
+			Subsystem: &ontap.UUIDRef{UUID: created_subsystem.UUID},
+			Namespace: &ontap.UUIDRef{Name: namespace},
+		})
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to map namespace %s to nvme subsystem, got error: %s", namespace, err))
+			r.deleteIncompleteSubsystem(ctx, created_subsystem, &resp.Diagnostics)
+			return
+		}
+	}
+
+	maps, err := r.client.WithContext(ctx).GetNVMESubsystemMaps(created_subsystem.UUID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme subsystem maps, got error: %s", err))
+		r.deleteIncompleteSubsystem(ctx, created_subsystem, &resp.Diagnostics)
+		return
+	}
+
+	data.fromNVMESubsystem(created_subsystem, maps)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// deleteIncompleteSubsystem removes a subsystem that couldn't be completed
+// after its creation, as it isn't saved in the state and would make the next
+// apply fail with a duplicate name
+func (r *NVMESubsystemResource) deleteIncompleteSubsystem(ctx context.Context, subsystem *ontap.NVMESubsystem, diags *diag.Diagnostics) {
+	err := r.client.WithContext(ctx).DeleteNVMESubsystem(subsystem)
+
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete incomplete nvme subsystem %s, it has to be deleted manually, got error: %s", subsystem.UUID, err))
+	}
+}
+
+func (r *NVMESubsystemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *NVMESubsystemResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subsystem, err := r.client.WithContext(ctx).GetNVMESubsystem(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme subsystem, got error: %s", err))
+		return
+	}
+
+	maps, err := r.client.WithContext(ctx).GetNVMESubsystemMaps(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme subsystem maps, got error: %s", err))
+		return
+	}
+
+	data.fromNVMESubsystem(subsystem, maps)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NVMESubsystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *NVMESubsystemResourceModel
+	var state *NVMESubsystemResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := state.UUID.Value
+
+	_, err := r.client.WithContext(ctx).UpdateNVMESubsystem(&ontap.NVMESubsystem{
+		UUID:    uuid,
+		Comment: stringPointerValue(plan.Comment),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update nvme subsystem, got error: %s", err))
+		return
+	}
+
+	// Hosts are changed one by one so hosts that stay in the subsystem keep
+	// access to their namespaces
+	added, removed := stringListDiff(stringListValues(state.Hosts), stringListValues(plan.Hosts))
+
+	for _, host := range added {
+		err = r.client.WithContext(ctx).AddNVMESubsystemHost(uuid, host)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add host %s to nvme subsystem, got error: %s", host, err))
+			return
+		}
+	}
+
+	for _, host := range removed {
+		err = r.client.WithContext(ctx).RemoveNVMESubsystemHost(uuid, host)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove host %s from nvme subsystem, got error: %s", host, err))
+			return
+		}
+	}
+
+	current_maps, err := r.client.WithContext(ctx).GetNVMESubsystemMaps(uuid)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme subsystem maps, got error: %s", err))
+		return
+	}
+
+	added, removed = stringListDiff(stringListValues(state.Namespaces), stringListValues(plan.Namespaces))
+
+	for _, namespace := range added {
+		err = r.client.WithContext(ctx).CreateNVMESubsystemMap(&ontap.NVMESubsystemMap{
+			SVM:       &ontap.UUIDRef{Name: state.SVM.Value},
+			Subsystem: &ontap.UUIDRef{UUID: uuid},
+			Namespace: &ontap.UUIDRef{Name: namespace},
+		})
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to map namespace %s to nvme subsystem, got error: %s", namespace, err))
+			return
+		}
+	}
+
+	for _, namespace := range removed {
+		for _, current_map := range current_maps {
+			if current_map.Namespace == nil || current_map.Namespace.Name != namespace {
+				continue
+			}
+
+			err = r.client.WithContext(ctx).DeleteNVMESubsystemMap(uuid, current_map.Namespace.UUID)
+
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unmap namespace %s from nvme subsystem, got error: %s", namespace, err))
+				return
+			}
+		}
+	}
+
+	updated_subsystem, err := r.client.WithContext(ctx).GetNVMESubsystem(uuid)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme subsystem, got error: %s", err))
+		return
+	}
+
+	maps, err := r.client.WithContext(ctx).GetNVMESubsystemMaps(uuid)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nvme subsystem maps, got error: %s", err))
+		return
+	}
+
+	plan.fromNVMESubsystem(updated_subsystem, maps)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *NVMESubsystemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *NVMESubsystemResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subsystem := ontap.NVMESubsystem{}
+	subsystem.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteNVMESubsystem(&subsystem)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete nvme subsystem, got error: %s", err))
+		return
+	}
+}
+
+func (r *NVMESubsystemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *NVMESubsystemResourceModel) fromNVMESubsystem(subsystem *ontap.NVMESubsystem, maps []ontap.NVMESubsystemMap) {
+	data.UUID = types.String{Value: subsystem.UUID}
+	data.ID = data.UUID
+	if subsystem.SVM != nil {
+		data.SVM = types.String{Value: subsystem.SVM.Name}
+	}
+	data.Name = types.String{Value: subsystem.Name}
+	data.OSType = types.String{Value: subsystem.OSType}
+	data.Comment = stringPointerModel(subsystem.Comment)
+
+	hosts := []string{}
+	for _, host := range subsystem.Hosts {
+		hosts = append(hosts, host.NQN)
+	}
+	data.Hosts = stringSetModel(data.Hosts, hosts)
+
+	namespaces := []string{}
+	for _, subsystem_map := range maps {
+		if subsystem_map.Namespace != nil {
+			namespaces = append(namespaces, subsystem_map.Namespace.Name)
+		}
+	}
+	data.Namespaces = stringSetModel(data.Namespaces, namespaces)
+
+	data.TargetNQN = types.String{Value: subsystem.TargetNQN}
+	data.SerialNumber = types.String{Value: subsystem.SerialNumber}
+}
@@ -0,0 +1,131 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNVMESubsystemResource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_nvme_subsystem")
+	testAccSimulator.AddVolume(svm_uuid, "vol_nvme_subsystem")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if testAccSimulator.NVMESubsystem("svm_nvme_subsystem", "k8s") != nil {
+				return fmt.Errorf("subsystem k8s still exists")
+			}
+			return testAccCheckNVMENamespaceDestroyed("svm_nvme_subsystem", "/vol/vol_nvme_subsystem/ns_a", "/vol/vol_nvme_subsystem/ns_b")(s)
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccNVMESubsystemResourceConfig(`["nqn.2014-08.org.nvmexpress:uuid:host1", "nqn.2014-08.org.nvmexpress:uuid:host2"]`, "a"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_nvme_subsystem.test", "uuid"),
+					resource.TestCheckResourceAttrSet("ontap_nvme_subsystem.test", "target_nqn"),
+					resource.TestCheckResourceAttrSet("ontap_nvme_subsystem.test", "serial_number"),
+					resource.TestCheckResourceAttr("ontap_nvme_subsystem.test", "hosts.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ontap_nvme_subsystem.test", "namespaces.*", "/vol/vol_nvme_subsystem/ns_a"),
+					testAccCheckNVMENamespaceMapped("svm_nvme_subsystem", "/vol/vol_nvme_subsystem/ns_a", true),
+					testAccCheckNVMENamespaceMapped("svm_nvme_subsystem", "/vol/vol_nvme_subsystem/ns_b", false),
+				),
+			},
+			// Update and Read testing, hosts and namespaces are changed in
+			// place
+			{
+				Config: testAccNVMESubsystemResourceConfig(`["nqn.2014-08.org.nvmexpress:uuid:host1", "nqn.2014-08.org.nvmexpress:uuid:host3"]`, "b"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_nvme_subsystem.test", "hosts.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ontap_nvme_subsystem.test", "hosts.*", "nqn.2014-08.org.nvmexpress:uuid:host3"),
+					resource.TestCheckResourceAttr("ontap_nvme_subsystem.test", "namespaces.#", "1"),
+					resource.TestCheckTypeSetElemAttr("ontap_nvme_subsystem.test", "namespaces.*", "/vol/vol_nvme_subsystem/ns_b"),
+					testAccCheckNVMENamespaceMapped("svm_nvme_subsystem", "/vol/vol_nvme_subsystem/ns_a", false),
+					testAccCheckNVMENamespaceMapped("svm_nvme_subsystem", "/vol/vol_nvme_subsystem/ns_b", true),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_nvme_subsystem.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase, the subsystem
+			// is deleted along with its maps
+		},
+	})
+}
+
+func TestAccNVMESubsystemResourceIncomplete(t *testing.T) {
+	testAccSimulator.AddSVM("svm_nvme_incomplete")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The subsystem is deleted when a namespace can't be mapped
+		CheckDestroy: func(s *terraform.State) error {
+			if testAccSimulator.NVMESubsystem("svm_nvme_incomplete", "incomplete") != nil {
+				return fmt.Errorf("subsystem incomplete still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig() + `
+resource "ontap_nvme_subsystem" "test" {
+  svm        = "svm_nvme_incomplete"
+  name       = "incomplete"
+  os_type    = "linux"
+  namespaces = ["/vol/missing/ns"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to map namespace /vol/missing/ns`),
+			},
+		},
+	})
+}
+
+func testAccNVMESubsystemResourceConfig(hosts string, namespace string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_nvme_namespace" "a" {
+  svm     = "svm_nvme_subsystem"
+  name    = "/vol/vol_nvme_subsystem/ns_a"
+  os_type = "linux"
+  size    = 1073741824
+}
+
+resource "ontap_nvme_namespace" "b" {
+  svm     = "svm_nvme_subsystem"
+  name    = "/vol/vol_nvme_subsystem/ns_b"
+  os_type = "linux"
+  size    = 1073741824
+}
+
+resource "ontap_nvme_subsystem" "test" {
+  svm        = "svm_nvme_subsystem"
+  name       = "k8s"
+  os_type    = "linux"
+  comment    = "created by acceptance tests"
+  hosts      = %s
+  namespaces = [ontap_nvme_namespace.%s.name]
+}
+`, hosts, namespace)
+}
+
+func testAccCheckNVMENamespaceMapped(svm_name string, path string, mapped bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		namespace := testAccSimulator.NVMENamespace(svm_name, path)
+		if namespace == nil {
+			return fmt.Errorf("namespace %s doesn't exist", path)
+		}
+		if testAccRecordField(namespace, "status.mapped") != fmt.Sprint(mapped) {
+			return fmt.Errorf("expected namespace %s mapped to be %t", path, mapped)
+		}
+		return nil
+	}
+}
@@ -2,9 +2,11 @@ package ontap
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -30,6 +32,11 @@ type ONTAPProviderModel struct {
 	Username        types.String `tfsdk:"username"`
 	Password        types.String `tfsdk:"password"`
 	IgnoreSSLErrors types.Bool   `tfsdk:"ignore_ssl_errors"`
+
+	MaxConnectionsPerHost types.Int64 `tfsdk:"max_connections_per_host"`
+	UseSessionCookie      types.Bool  `tfsdk:"use_session_cookie"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Int64 `tfsdk:"requests_per_second"`
 }
 
 func (p *ONTAPProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -41,7 +48,7 @@ func (p *ONTAPProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagn
 	return tfsdk.Schema{
 		Attributes: map[string]tfsdk.Attribute{
 			"hostname": {
-				MarkdownDescription: "ONTAP Management Hostname",
+				MarkdownDescription: "ONTAP Management Hostname, with an optional port, i.e. `cluster1:8443`. A URL can be used to set the scheme and the path prefix of the REST API, i.e. `http://proxy:8080/ontap` when the cluster is reached through a reverse proxy",
 				Type:                types.StringType,
 				Required:            true,
 			},
@@ -62,6 +69,35 @@ func (p *ONTAPProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagn
 				Type:                types.BoolType,
 				Optional:            true,
 			},
+			"max_connections_per_host": {
+				MarkdownDescription: fmt.Sprintf("Maximum number of connections opened to the cluster, idle connections are kept alive to be reused. Defaults to `%d`", ontap.DefaultMaxConnsPerHost),
+				Type:                types.Int64Type,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					int64AtLeast(1),
+				},
+			},
+			"use_session_cookie": {
+				MarkdownDescription: "Authenticate requests with the session cookie returned by the cluster after the first login instead of sending the credentials every time",
+				Type:                types.BoolType,
+				Optional:            true,
+			},
+			"max_concurrent_requests": {
+				MarkdownDescription: "Maximum number of requests sent to the cluster at the same time, whatever the Terraform parallelism. Unlimited by default",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					int64AtLeast(1),
+				},
+			},
+			"requests_per_second": {
+				MarkdownDescription: "Maximum number of requests sent to the cluster per second, to stay below the API throttling of the cluster. Unlimited by default",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					int64AtLeast(1),
+				},
+			},
 		},
 	}, nil
 }
@@ -83,7 +119,54 @@ func (p *ONTAPProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		data.IgnoreSSLErrors.Value = false
 	}
 
-	client, _ := ontap.NewClient(&data.Host.Value, &data.Username.Value, &data.Password.Value, data.IgnoreSSLErrors.Value)
+	options := []ontap.ClientOption{}
+
+	if !data.MaxConnectionsPerHost.Null && !data.MaxConnectionsPerHost.Unknown {
+		options = append(options, ontap.WithMaxConnsPerHost(int(data.MaxConnectionsPerHost.Value)))
+	}
+
+	if data.UseSessionCookie.Value {
+		options = append(options, ontap.WithSessionCookie())
+	}
+
+	if !data.MaxConcurrentRequests.Null && !data.MaxConcurrentRequests.Unknown {
+		options = append(options, ontap.WithMaxConcurrentRequests(int(data.MaxConcurrentRequests.Value)))
+	}
+
+	if !data.RequestsPerSecond.Null && !data.RequestsPerSecond.Unknown {
+		options = append(options, ontap.WithRequestsPerSecond(int(data.RequestsPerSecond.Value)))
+	}
+
+	// The hostname is validated once it is known
+	var host *string
+	if !data.Host.Unknown {
+		host = &data.Host.Value
+	}
+
+	client, err := ontap.NewClient(host, &data.Username.Value, &data.Password.Value, data.IgnoreSSLErrors.Value, options...)
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Invalid ONTAP Hostname",
+			fmt.Sprintf("The hostname must be a host name or address with an optional port, or an http or https URL, got error: %s", err),
+		)
+		return
+	}
+
+	// The version is used to check attributes against the cluster at plan
+	// time, resources still work without it. It is set on the shared client
+	// as the copy returned by WithContext is discarded
+	if !data.Host.Unknown && !data.Username.Unknown && !data.Password.Unknown {
+		client.Version, err = client.WithContext(ctx).DetectVersion()
+
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Detect ONTAP Version",
+				fmt.Sprintf("Attributes requiring a recent ONTAP release won't be checked at plan time, got error: %s", err),
+			)
+		}
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -93,13 +176,39 @@ func (p *ONTAPProvider) Resources(ctx context.Context) []func() resource.Resourc
 	return []func() resource.Resource{
 		NewQtreeResource,
 		NewSVMResource,
+		NewClusterScheduleResource,
+		NewSnapshotResource,
+		NewSnapmirrorRelationshipResource,
+		NewSnapmirrorPolicyResource,
+		NewClusterPeerResource,
+		NewSVMPeerResource,
+		NewLUNResource,
+		NewIGroupResource,
+		NewLUNMapResource,
+		NewISCSIServiceResource,
+		NewFCPServiceResource,
+		NewISCSICredentialsResource,
+		NewNVMEServiceResource,
+		NewNVMENamespaceResource,
+		NewNVMESubsystemResource,
+		NewS3ServiceResource,
+		NewS3BucketResource,
+		NewS3UserResource,
+		NewQOSPolicyResource,
 	}
 }
 
 func (p *ONTAPProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewQtreeDataSource,
+		NewQtreesDataSource,
 		NewSVMDataSource,
+		NewSVMsDataSource,
+		NewSnapshotsDataSource,
+		NewLUNDataSource,
+		NewAggregatesDataSource,
+		NewClusterDataSource,
+		NewClusterNodesDataSource,
 	}
 }
 
@@ -1,10 +1,23 @@
 package ontap
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -12,7 +25,61 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"ontap": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccSimulator is the in-memory ONTAP REST API acceptance tests run
+// against, providers are configured with testAccProviderConfig to use it
+var testAccSimulator *ontaptest.Simulator
+
+func TestMain(m *testing.M) {
+	testAccSimulator = ontaptest.NewSimulator()
+
+	code := m.Run()
+
+	testAccSimulator.Close()
+	os.Exit(code)
+}
+
+// testAccProviderConfig returns the provider configuration for the simulator
+func testAccProviderConfig() string {
+	return testAccSimulatorProviderConfig(testAccSimulator)
+}
+
+// testAccSimulatorProviderConfig returns the provider configuration for
+// simulator, i.e. one running another ONTAP release
+func testAccSimulatorProviderConfig(simulator *ontaptest.Simulator) string {
+	return fmt.Sprintf(`
+provider "ontap" {
+  hostname          = %q
+  username          = "admin"
+  password          = "password"
+  ignore_ssl_errors = true
+}
+`, simulator.Host())
+}
+
+// testAccRecordField returns the value at the dotted path field of a record
+// returned by the simulator as a string, or an empty string when it isn't set.
+// Like in the state, # is the length of a list.
+func testAccRecordField(record map[string]interface{}, field string) string {
+	var value interface{} = record
+
+	for _, key := range strings.Split(field, ".") {
+		if list, ok := value.([]interface{}); ok && key == "#" {
+			return fmt.Sprint(len(list))
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -20,3 +87,201 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestProviderConfigureDetectsVersion(t *testing.T) {
+	client := testProviderConfigure(t, testAccSimulator, nil)
+
+	if client.Version == nil || client.Version.Generation != 9 || client.Version.Major != 11 {
+		t.Errorf("expected version 9.11 to be detected, got %+v", client.Version)
+	}
+}
+
+func TestProviderConfigureSessionCookie(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	client := testProviderConfigure(t, simulator, map[string]tftypes.Value{
+		"use_session_cookie":       tftypes.NewValue(tftypes.Bool, true),
+		"max_connections_per_host": tftypes.NewValue(tftypes.Number, 2),
+	})
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport.MaxConnsPerHost != 2 {
+		t.Errorf("expected a transport with 2 connections per host, got %#v", client.HTTPClient.Transport)
+	}
+
+	// The version was detected with the credentials, the following requests
+	// use the session
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSVMs(nil)
+
+		if err != nil {
+			t.Fatalf("unable to get svms, got error: %s", err)
+		}
+	}
+
+	if logins := simulator.Logins(); logins != 1 {
+		t.Errorf("expected a single login, got %d", logins)
+	}
+}
+
+func TestAccProviderSessionCookie(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	svm_uuid := simulator.AddSVM("svm_session")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_session")
+
+	config := func(permissions int) string {
+		return fmt.Sprintf(`
+provider "ontap" {
+  hostname                 = %q
+  username                 = "admin"
+  password                 = "password"
+  ignore_ssl_errors        = true
+  use_session_cookie       = true
+  max_connections_per_host = 2
+}
+
+resource "ontap_qtree" "test" {
+  svm_uuid         = %q
+  volume_uuid      = %q
+  name             = "home"
+  security_style   = "unix"
+  unix_permissions = %d
+}
+`, simulator.Host(), svm_uuid, volume_uuid, permissions)
+	}
+
+	// Each Terraform command configures a new provider, which logs in with
+	// its first request and then uses the session
+	checkSessions := func(s *terraform.State) error {
+		logins, requests := simulator.Logins(), len(simulator.Requests())
+		if logins >= requests {
+			return fmt.Errorf("expected requests to use the session, got %d logins for %d requests", logins, requests)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(755),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test", "unix_permissions", "755"),
+					checkSessions,
+				),
+			},
+			// Sessions expired by the cluster are opened again
+			{
+				PreConfig: simulator.ExpireSessions,
+				Config:    config(700),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test", "unix_permissions", "700"),
+					checkSessions,
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderLimits(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	// Terraform creates the qtrees in parallel, the requests take long enough
+	// to overlap
+	simulator.Latency = 20 * time.Millisecond
+
+	svm_uuid := simulator.AddSVM("svm_limits")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_limits")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "ontap" {
+  hostname                = %q
+  username                = "admin"
+  password                = "password"
+  ignore_ssl_errors       = true
+  max_concurrent_requests = 2
+  requests_per_second     = 100
+}
+
+resource "ontap_qtree" "test" {
+  count = 8
+
+  svm_uuid         = %q
+  volume_uuid      = %q
+  name             = "home_${count.index}"
+  security_style   = "unix"
+  unix_permissions = 755
+}
+`, simulator.Host(), svm_uuid, volume_uuid),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test.7", "name", "home_7"),
+					func(s *terraform.State) error {
+						if peak := simulator.PeakConcurrency(); peak > 2 {
+							return fmt.Errorf("expected at most 2 requests at the same time, got %d", peak)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testProviderConfigure configures the provider for simulator, with the
+// attributes overriding the defaults, and returns its client
+func testProviderConfigure(t *testing.T, simulator *ontaptest.Simulator, attributes map[string]tftypes.Value) *ontap.Client {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schema, diags := p.GetSchema(ctx)
+
+	if diags.HasError() {
+		t.Fatalf("unable to get provider schema: %v", diags)
+	}
+
+	config := map[string]tftypes.Value{}
+	for name, attribute := range schema.Attributes {
+		config[name] = tftypes.NewValue(attribute.FrameworkType().TerraformType(ctx), nil)
+	}
+	config["hostname"] = tftypes.NewValue(tftypes.String, simulator.Host())
+	config["username"] = tftypes.NewValue(tftypes.String, "admin")
+	config["password"] = tftypes.NewValue(tftypes.String, "password")
+	config["ignore_ssl_errors"] = tftypes.NewValue(tftypes.Bool, true)
+	for name, value := range attributes {
+		config[name] = value
+	}
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schema,
+			Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), config),
+		},
+	}
+	resp := provider.ConfigureResponse{}
+
+	p.Configure(ctx, req, &resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	client, ok := resp.ResourceData.(*ontap.Client)
+
+	if !ok {
+		t.Fatalf("expected *ontap.Client, got %T", resp.ResourceData)
+	}
+
+	return client
+}
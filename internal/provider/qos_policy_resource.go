@@ -0,0 +1,427 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &QOSPolicyResource{}
+var _ resource.ResourceWithImportState = &QOSPolicyResource{}
+var _ resource.ResourceWithValidateConfig = &QOSPolicyResource{}
+
+func NewQOSPolicyResource() resource.Resource {
+	return &QOSPolicyResource{}
+}
+
+// QOSPolicyResource defines the resource implementation.
+type QOSPolicyResource struct {
+	client *ontap.Client
+}
+
+// QOSPolicyResourceModel describes the resource data model.
+type QOSPolicyResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SVM      types.String                    `tfsdk:"svm"`
+	Name     types.String                    `tfsdk:"name"`
+	Fixed    *QOSPolicyFixedResourceModel    `tfsdk:"fixed"`
+	Adaptive *QOSPolicyAdaptiveResourceModel `tfsdk:"adaptive"`
+}
+
+type QOSPolicyFixedResourceModel struct {
+	MinThroughputIOPS types.Int64 `tfsdk:"min_throughput_iops"`
+	MaxThroughputIOPS types.Int64 `tfsdk:"max_throughput_iops"`
+	MinThroughputMBPS types.Int64 `tfsdk:"min_throughput_mbps"`
+	MaxThroughputMBPS types.Int64 `tfsdk:"max_throughput_mbps"`
+	CapacityShared    types.Bool  `tfsdk:"capacity_shared"`
+}
+
+type QOSPolicyAdaptiveResourceModel struct {
+	ExpectedIOPS           types.Int64  `tfsdk:"expected_iops"`
+	PeakIOPS               types.Int64  `tfsdk:"peak_iops"`
+	AbsoluteMinIOPS        types.Int64  `tfsdk:"absolute_min_iops"`
+	ExpectedIOPSAllocation types.String `tfsdk:"expected_iops_allocation"`
+	PeakIOPSAllocation     types.String `tfsdk:"peak_iops_allocation"`
+	BlockSize              types.String `tfsdk:"block_size"`
+}
+
+// A policy can't be converted between fixed and adaptive, it has to be
+// replaced when the configured type changes
+var qosPolicyTypeChanged = resource.RequiresReplaceIf(
+	func(ctx context.Context, state, config attr.Value, path path.Path) (bool, diag.Diagnostics) {
+		return state.IsNull() != config.IsNull(), nil
+	},
+	"Switching between fixed and adaptive policies replaces the policy",
+	"Switching between fixed and adaptive policies replaces the policy",
+)
+
+func (r *QOSPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_qos_policy"
+}
+
+func (r *QOSPolicyResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A QoS policy group, either `fixed` with absolute limits or `adaptive` with limits scaling with the size of the objects it is applied to",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the policy, its UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "QoS policy UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM owning the policy",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "QoS policy name",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"fixed": {
+				MarkdownDescription: "Fixed limits, an omitted limit is not enforced",
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					qosPolicyTypeChanged,
+				},
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"min_throughput_iops": {
+						MarkdownDescription: "Guaranteed IOPS",
+						Type:                types.Int64Type,
+						Optional:            true,
+					},
+					"max_throughput_iops": {
+						MarkdownDescription: "Maximum IOPS",
+						Type:                types.Int64Type,
+						Optional:            true,
+					},
+					"min_throughput_mbps": {
+						MarkdownDescription: "Guaranteed throughput in MB/s",
+						Type:                types.Int64Type,
+						Optional:            true,
+					},
+					"max_throughput_mbps": {
+						MarkdownDescription: "Maximum throughput in MB/s",
+						Type:                types.Int64Type,
+						Optional:            true,
+					},
+					"capacity_shared": {
+						MarkdownDescription: "Whether the limits are shared by all the objects the policy is applied to instead of applying to each of them",
+						Type:                types.BoolType,
+						Optional:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							resource.RequiresReplace(),
+						},
+					},
+				}),
+			},
+			"adaptive": {
+				MarkdownDescription: "Adaptive limits, expressed in IOPS per TB",
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					qosPolicyTypeChanged,
+				},
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"expected_iops": {
+						MarkdownDescription: "Expected IOPS per TB",
+						Type:                types.Int64Type,
+						Required:            true,
+					},
+					"peak_iops": {
+						MarkdownDescription: "Peak IOPS per TB",
+						Type:                types.Int64Type,
+						Required:            true,
+					},
+					"absolute_min_iops": {
+						MarkdownDescription: "Minimum IOPS allowed regardless of the size of the object",
+						Type:                types.Int64Type,
+						Optional:            true,
+					},
+					"expected_iops_allocation": {
+						MarkdownDescription: "Size used to compute expected IOPS, `allocated_space` or `used_space`",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf("allocated_space", "used_space"),
+						},
+					},
+					"peak_iops_allocation": {
+						MarkdownDescription: "Size used to compute peak IOPS, `allocated_space` or `used_space`",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf("allocated_space", "used_space"),
+						},
+					},
+					"block_size": {
+						MarkdownDescription: "Block size used to convert IOPS to throughput, i.e. `any` or `32k`",
+						Type:                types.StringType,
+						Optional:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf("any", "4k", "8k", "16k", "32k", "64k", "128k"),
+						},
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (r *QOSPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var fixed types.Object
+	var adaptive types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("fixed"), &fixed)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("adaptive"), &adaptive)...)
+
+	if resp.Diagnostics.HasError() || fixed.Unknown || adaptive.Unknown {
+		return
+	}
+
+	if !fixed.Null && !adaptive.Null {
+		resp.Diagnostics.AddAttributeError(path.Root("adaptive"), "Invalid Attribute Combination", "fixed and adaptive can't be set on the same policy")
+	}
+	if fixed.Null && adaptive.Null {
+		resp.Diagnostics.AddError("Missing Attribute", "One of fixed or adaptive is required")
+	}
+}
+
+func (r *QOSPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *QOSPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *QOSPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := data.toQOSPolicy()
+	policy.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+
+	created_policy, err := r.client.WithContext(ctx).CreateQOSPolicy(&policy)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create qos policy, got error: %s", err))
+		return
+	}
+
+	data.fromQOSPolicy(created_policy)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QOSPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *QOSPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.WithContext(ctx).GetQOSPolicy(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read qos policy, got error: %s", err))
+		return
+	}
+
+	data.fromQOSPolicy(policy)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *QOSPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *QOSPolicyResourceModel
+	var state *QOSPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := plan.toQOSPolicy()
+	policy.UUID = state.UUID.Value
+
+	updated_policy, err := r.client.WithContext(ctx).UpdateQOSPolicy(&policy)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update qos policy, got error: %s", err))
+		return
+	}
+
+	plan.fromQOSPolicy(updated_policy)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *QOSPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *QOSPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteQOSPolicy(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete qos policy, got error: %s", err))
+		return
+	}
+}
+
+func (r *QOSPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *QOSPolicyResourceModel) toQOSPolicy() ontap.QOSPolicy {
+	policy := ontap.QOSPolicy{}
+	policy.Name = data.Name.Value
+
+	if data.Fixed != nil {
+		policy.Fixed = &ontap.QOSPolicyFixed{
+			MinThroughputIOPS: data.Fixed.MinThroughputIOPS.Value,
+			MaxThroughputIOPS: data.Fixed.MaxThroughputIOPS.Value,
+			MinThroughputMBPS: data.Fixed.MinThroughputMBPS.Value,
+			MaxThroughputMBPS: data.Fixed.MaxThroughputMBPS.Value,
+		}
+		if !data.Fixed.CapacityShared.Null && !data.Fixed.CapacityShared.Unknown {
+			policy.Fixed.CapacityShared = &data.Fixed.CapacityShared.Value
+		}
+	}
+
+	if data.Adaptive != nil {
+		policy.Adaptive = &ontap.QOSPolicyAdaptive{
+			ExpectedIOPS:           data.Adaptive.ExpectedIOPS.Value,
+			PeakIOPS:               data.Adaptive.PeakIOPS.Value,
+			AbsoluteMinIOPS:        data.Adaptive.AbsoluteMinIOPS.Value,
+			ExpectedIOPSAllocation: data.Adaptive.ExpectedIOPSAllocation.Value,
+			PeakIOPSAllocation:     data.Adaptive.PeakIOPSAllocation.Value,
+			BlockSize:              data.Adaptive.BlockSize.Value,
+		}
+	}
+
+	return policy
+}
+
+// fromQOSPolicy updates the model from ONTAP, unset fixed limits are returned
+// as 0 and adaptive settings that ONTAP defaults are only refreshed when they
+// are configured
+func (data *QOSPolicyResourceModel) fromQOSPolicy(policy *ontap.QOSPolicy) {
+	data.UUID = types.String{Value: policy.UUID}
+	data.ID = data.UUID
+	if policy.SVM != nil {
+		data.SVM = types.String{Value: policy.SVM.Name}
+	}
+	data.Name = types.String{Value: policy.Name}
+
+	if policy.Fixed != nil {
+		fixed := QOSPolicyFixedResourceModel{
+			MinThroughputIOPS: qosLimitModel(policy.Fixed.MinThroughputIOPS),
+			MaxThroughputIOPS: qosLimitModel(policy.Fixed.MaxThroughputIOPS),
+			MinThroughputMBPS: qosLimitModel(policy.Fixed.MinThroughputMBPS),
+			MaxThroughputMBPS: qosLimitModel(policy.Fixed.MaxThroughputMBPS),
+			CapacityShared:    types.Bool{Null: true},
+		}
+		if policy.Fixed.CapacityShared != nil && (*policy.Fixed.CapacityShared || (data.Fixed != nil && !data.Fixed.CapacityShared.Null)) {
+			fixed.CapacityShared = types.Bool{Value: *policy.Fixed.CapacityShared}
+		}
+		data.Fixed = &fixed
+	} else {
+		data.Fixed = nil
+	}
+
+	if policy.Adaptive != nil {
+		adaptive := QOSPolicyAdaptiveResourceModel{
+			ExpectedIOPS:           types.Int64{Value: policy.Adaptive.ExpectedIOPS},
+			PeakIOPS:               types.Int64{Value: policy.Adaptive.PeakIOPS},
+			AbsoluteMinIOPS:        types.Int64{Null: true},
+			ExpectedIOPSAllocation: types.String{Null: true},
+			PeakIOPSAllocation:     types.String{Null: true},
+			BlockSize:              types.String{Null: true},
+		}
+		if data.Adaptive != nil {
+			if !data.Adaptive.AbsoluteMinIOPS.Null {
+				adaptive.AbsoluteMinIOPS = types.Int64{Value: policy.Adaptive.AbsoluteMinIOPS}
+			}
+			if !data.Adaptive.ExpectedIOPSAllocation.Null {
+				adaptive.ExpectedIOPSAllocation = stringModel(policy.Adaptive.ExpectedIOPSAllocation)
+			}
+			if !data.Adaptive.PeakIOPSAllocation.Null {
+				adaptive.PeakIOPSAllocation = stringModel(policy.Adaptive.PeakIOPSAllocation)
+			}
+			if !data.Adaptive.BlockSize.Null {
+				adaptive.BlockSize = stringModel(policy.Adaptive.BlockSize)
+			}
+		}
+		data.Adaptive = &adaptive
+	} else {
+		data.Adaptive = nil
+	}
+}
+
+// qosLimitModel returns a null value for a limit that is not enforced
+func qosLimitModel(limit int64) types.Int64 {
+	if limit == 0 {
+		return types.Int64{Null: true}
+	}
+
+	return types.Int64{Value: limit}
+}
@@ -0,0 +1,130 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccQOSPolicyResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_qos")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckQOSPolicyDestroyed("svm_qos", "gold", "platinum"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, omitted limits are not enforced
+			{
+				Config: testAccQOSPolicyResourceConfig("gold", `
+  fixed = {
+    max_throughput_iops = 1000
+    max_throughput_mbps = 100
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_qos_policy.test", "uuid"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "fixed.max_throughput_iops", "1000"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "fixed.max_throughput_mbps", "100"),
+					resource.TestCheckNoResourceAttr("ontap_qos_policy.test", "fixed.min_throughput_iops"),
+					resource.TestCheckNoResourceAttr("ontap_qos_policy.test", "fixed.capacity_shared"),
+					resource.TestCheckNoResourceAttr("ontap_qos_policy.test", "adaptive"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccQOSPolicyResourceConfig("platinum", `
+  fixed = {
+    min_throughput_iops = 500
+    max_throughput_iops = 5000
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "name", "platinum"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "fixed.min_throughput_iops", "500"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "fixed.max_throughput_iops", "5000"),
+					resource.TestCheckNoResourceAttr("ontap_qos_policy.test", "fixed.max_throughput_mbps"),
+					func(s *terraform.State) error {
+						policy := testAccSimulator.QOSPolicy("svm_qos", "platinum")
+						if policy == nil || testAccRecordField(policy, "fixed.max_throughput_mbps") != "0" {
+							return fmt.Errorf("expected policy platinum without throughput limit, got %v", policy)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_qos_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Switching to an adaptive policy replaces it
+			{
+				Config: testAccQOSPolicyResourceConfig("platinum", `
+  adaptive = {
+    expected_iops = 1024
+    peak_iops     = 2048
+    block_size    = "32k"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ontap_qos_policy.test", "fixed"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "adaptive.expected_iops", "1024"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "adaptive.peak_iops", "2048"),
+					resource.TestCheckResourceAttr("ontap_qos_policy.test", "adaptive.block_size", "32k"),
+					resource.TestCheckNoResourceAttr("ontap_qos_policy.test", "adaptive.absolute_min_iops"),
+					func(s *terraform.State) error {
+						policy := testAccSimulator.QOSPolicy("svm_qos", "platinum")
+						if policy == nil || policy["fixed"] != nil || testAccRecordField(policy, "adaptive.absolute_min_iops") != "75" {
+							return fmt.Errorf("expected adaptive policy platinum, got %v", policy)
+						}
+						return nil
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccQOSPolicyResource_Invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQOSPolicyResourceConfig("both", `
+  fixed = {
+    max_throughput_iops = 1000
+  }
+  adaptive = {
+    expected_iops = 1024
+    peak_iops     = 2048
+  }`),
+				ExpectError: regexp.MustCompile(`fixed and adaptive can't be set on the same\s+policy`),
+			},
+		},
+	})
+}
+
+func testAccQOSPolicyResourceConfig(name string, limits string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_qos_policy" "test" {
+  svm  = "svm_qos"
+  name = %q
+%s
+}
+`, name, limits)
+}
+
+func testAccCheckQOSPolicyDestroyed(svm_name string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.QOSPolicy(svm_name, name) != nil {
+				return fmt.Errorf("qos policy %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
@@ -35,6 +35,7 @@ type QtreeDataSourceModel struct {
 	Path           types.String `tfsdk:"path"`
 	SecurityStyle  types.String `tfsdk:"security_style"`
 	UnixPermission types.Int64  `tfsdk:"unix_permissions"`
+	QOSPolicy      types.String `tfsdk:"qos_policy"`
 }
 
 func (d *QtreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -82,6 +83,11 @@ func (d *QtreeDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Dia
 				Type:                types.Int64Type,
 				Computed:            true,
 			},
+			"qos_policy": {
+				MarkdownDescription: "Name of the QoS policy applied to the qtree",
+				Type:                types.StringType,
+				Computed:            true,
+			},
 		},
 	}, nil
 }
@@ -118,7 +124,7 @@ func (d *QtreeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	qtree, err := d.client.GetQtree(data.UUID.Value, "")
+	qtree, err := d.client.WithContext(ctx).GetQtree(data.UUID.Value, "")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
 		return
@@ -133,6 +139,7 @@ func (d *QtreeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.Path = types.String{Value: qtree.Path}
 	data.SecurityStyle = types.String{Value: qtree.SecurityStyle}
 	data.UnixPermission = types.Int64{Value: int64(qtree.UnixPermission)}
+	data.QOSPolicy = qtreeQOSPolicyModel(qtree)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -16,6 +16,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &QtreeResource{}
 var _ resource.ResourceWithImportState = &QtreeResource{}
+var _ resource.ResourceWithModifyPlan = &QtreeResource{}
 
 func NewQtreeResource() resource.Resource {
 	return &QtreeResource{}
@@ -38,6 +39,7 @@ type QtreeResourceModel struct {
 	Path           types.String `tfsdk:"path"`
 	SecurityStyle  types.String `tfsdk:"security_style"`
 	UnixPermission types.Int64  `tfsdk:"unix_permissions"`
+	QOSPolicy      types.String `tfsdk:"qos_policy"`
 }
 
 func (r *QtreeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -92,6 +94,11 @@ func (r *QtreeResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagn
 				Optional:            true,
 				Computed:            true,
 			},
+			"qos_policy": {
+				MarkdownDescription: "Name of the QoS policy applied to the qtree",
+				Type:                types.StringType,
+				Optional:            true,
+			},
 		},
 	}, nil
 }
@@ -116,6 +123,20 @@ func (r *QtreeResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+// Attributes of ontap_qtree that require a recent ONTAP release
+var qtreeAttributeVersions = []attributeVersion{
+	{Path: path.Root("qos_policy"), Generation: 9, Major: 8, Minor: 0},
+}
+
+func (r *QtreeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkAttributeVersions(ctx, r.client, req.Config, qtreeAttributeVersions)...)
+}
+
 func (r *QtreeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *QtreeResourceModel
 
@@ -142,8 +163,11 @@ func (r *QtreeResource) Create(ctx context.Context, req resource.CreateRequest,
 	qtree.VolumeUUID = data.VolumeUUID.Value
 	qtree.SecurityStyle = data.SecurityStyle.Value
 	qtree.UnixPermission = data.UnixPermission.Value
+	if !data.QOSPolicy.Null {
+		qtree.QOSPolicy = &ontap.UUIDRef{Name: data.QOSPolicy.Value}
+	}
 
-	created_qtree, err := r.client.CreateQtree(&qtree)
+	created_qtree, err := r.client.WithContext(ctx).CreateQtree(&qtree)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create qtree, got error: %s", err))
@@ -160,6 +184,7 @@ func (r *QtreeResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.UnixPermission = types.Int64{Value: created_qtree.UnixPermission}
 	data.Path = types.String{Value: created_qtree.Path}
 	data.QtreeID = types.Int64{Value: created_qtree.Id}
+	data.QOSPolicy = qtreeQOSPolicyModel(created_qtree)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -174,7 +199,7 @@ func (r *QtreeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	qtree, err := r.client.GetQtree(data.Id.Value, "")
+	qtree, err := r.client.WithContext(ctx).GetQtree(data.Id.Value, "")
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read qtree, got error: %s", err))
 		return
@@ -186,6 +211,7 @@ func (r *QtreeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Path = types.String{Value: qtree.Path}
 	data.SecurityStyle = types.String{Value: qtree.SecurityStyle}
 	data.UnixPermission = types.Int64{Value: qtree.UnixPermission}
+	data.QOSPolicy = qtreeQOSPolicyModel(qtree)
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
 	// httpResp, err := d.client.Do(httpReq)
@@ -223,13 +249,20 @@ func (r *QtreeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	qtree.SecurityStyle = plan.SecurityStyle.Value
 	qtree.UnixPermission = plan.UnixPermission.Value
 
+	// Removing the policy from the configuration detaches it from the qtree
+	if !plan.QOSPolicy.Null {
+		qtree.QOSPolicy = &ontap.UUIDRef{Name: plan.QOSPolicy.Value}
+	} else if !state.QOSPolicy.Null {
+		qtree.QOSPolicy = &ontap.UUIDRef{Name: "none"}
+	}
+
 	// tflog.Trace(ctx, "creating a QTREE +%v", map[string]interface{}{
 	// 	"data":   data,
 	// 	"qtree":  qtree,
 	// 	"__uuid": data.Id.Value,
 	// })
 
-	updated_qtree, err := r.client.UpdateQtree(&qtree)
+	updated_qtree, err := r.client.WithContext(ctx).UpdateQtree(&qtree)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update qtree, got error: %s", err))
 		return
@@ -246,6 +279,7 @@ func (r *QtreeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	plan.UnixPermission = types.Int64{Value: updated_qtree.UnixPermission}
 	plan.Path = types.String{Value: updated_qtree.Path}
 	plan.QtreeID = types.Int64{Value: updated_qtree.Id}
+	plan.QOSPolicy = qtreeQOSPolicyModel(updated_qtree)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -265,7 +299,7 @@ func (r *QtreeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	qtree.UUID = data.Id.Value
 	qtree.VolumeUUID = data.VolumeUUID.Value
 
-	r.client.DeleteQtree(&qtree)
+	r.client.WithContext(ctx).DeleteQtree(&qtree)
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
@@ -279,3 +313,11 @@ func (r *QtreeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *QtreeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func qtreeQOSPolicyModel(qtree *ontap.Qtree) types.String {
+	if qtree.QOSPolicy == nil {
+		return types.String{Null: true}
+	}
+
+	return stringModel(qtree.QOSPolicy.Name)
+}
@@ -0,0 +1,189 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestAccQtreeResource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_qtree")
+	volume_uuid := testAccSimulator.AddVolume(svm_uuid, "vol_qtree")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckQtreeDestroyed(volume_uuid, "home", "users"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccQtreeResourceConfig(svm_uuid, volume_uuid, "home", 755),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test", "name", "home"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "id", "1"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "uuid", volume_uuid+"/1"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "path", "/vol_qtree/home"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "unix_permissions", "755"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccQtreeResourceConfig(svm_uuid, volume_uuid, "users", 700),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test", "name", "users"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "uuid", volume_uuid+"/1"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "path", "/vol_qtree/users"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "unix_permissions", "700"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccQtreeResource_QOSPolicy(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_qtree_qos")
+	volume_uuid := testAccSimulator.AddVolume(svm_uuid, "vol_qtree_qos")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckQtreeDestroyed(volume_uuid, "tenant_a"),
+			testAccCheckQOSPolicyDestroyed("svm_qtree_qos", "gold"),
+		),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the policy is applied to the qtree
+			{
+				Config: testAccQtreeResourceQOSPolicyConfig(svm_uuid, volume_uuid, "qos_policy = ontap_qos_policy.gold.name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test", "qos_policy", "gold"),
+					func(s *terraform.State) error {
+						qtree := testAccSimulator.Qtree(volume_uuid, "tenant_a")
+						if qtree == nil || testAccRecordField(qtree, "qos_policy.name") != "gold" {
+							return fmt.Errorf("expected qtree tenant_a with policy gold, got %v", qtree)
+						}
+						return nil
+					},
+				),
+			},
+			// Update and Read testing, the policy is detached when removed
+			{
+				Config: testAccQtreeResourceQOSPolicyConfig(svm_uuid, volume_uuid, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ontap_qtree.test", "qos_policy"),
+					func(s *terraform.State) error {
+						qtree := testAccSimulator.Qtree(volume_uuid, "tenant_a")
+						if qtree == nil || qtree["qos_policy"] != nil {
+							return fmt.Errorf("expected qtree tenant_a without policy, got %v", qtree)
+						}
+						return nil
+					},
+				),
+			},
+			// Delete testing automatically occurs in TestCase, the qtree is
+			// deleted before the policy applied to it
+			{
+				Config: testAccQtreeResourceQOSPolicyConfig(svm_uuid, volume_uuid, "qos_policy = ontap_qos_policy.gold.name"),
+				Check:  resource.TestCheckResourceAttr("ontap_qtree.test", "qos_policy", "gold"),
+			},
+		},
+	})
+}
+
+func testAccQtreeResourceConfig(svm_uuid string, volume_uuid string, name string, unix_permissions int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_qtree" "test" {
+  svm_uuid         = %q
+  volume_uuid      = %q
+  name             = %q
+  security_style   = "unix"
+  unix_permissions = %d
+}
+`, svm_uuid, volume_uuid, name, unix_permissions)
+}
+
+func testAccQtreeResourceQOSPolicyConfig(svm_uuid string, volume_uuid string, qos_policy string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_qos_policy" "gold" {
+  svm  = "svm_qtree_qos"
+  name = "gold"
+
+  fixed = {
+    max_throughput_iops = 10000
+  }
+}
+
+resource "ontap_qtree" "test" {
+  svm_uuid         = %q
+  volume_uuid      = %q
+  name             = "tenant_a"
+  security_style   = "unix"
+  unix_permissions = 755
+  %s
+}
+`, svm_uuid, volume_uuid, qos_policy)
+}
+
+func TestAccQtreeResource_Before98(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	// ONTAP 9.7 rejects qos_policy as an unknown field, so it must be
+	// neither requested nor set
+	simulator.SetVersion(9, 7, 0)
+	svm_uuid := simulator.AddSVM("svm_qtree_97")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_qtree_97")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if simulator.Qtree(volume_uuid, "home") != nil {
+				return fmt.Errorf("qtree home still exists")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSimulatorProviderConfig(simulator) + fmt.Sprintf(`
+resource "ontap_qtree" "test" {
+  svm_uuid         = %q
+  volume_uuid      = %q
+  name             = "home"
+  security_style   = "unix"
+  unix_permissions = 755
+}
+
+data "ontap_qtree" "test" {
+  uuid = ontap_qtree.test.uuid
+}
+`, svm_uuid, volume_uuid),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_qtree.test", "uuid", volume_uuid+"/1"),
+					resource.TestCheckResourceAttr("ontap_qtree.test", "path", "/vol_qtree_97/home"),
+					resource.TestCheckNoResourceAttr("ontap_qtree.test", "qos_policy"),
+					resource.TestCheckResourceAttr("data.ontap_qtree.test", "name", "home"),
+					resource.TestCheckResourceAttr("data.ontap_qtree.test", "unix_permissions", "755"),
+					resource.TestCheckNoResourceAttr("data.ontap_qtree.test", "qos_policy"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCheckQtreeDestroyed(volume_uuid string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.Qtree(volume_uuid, name) != nil {
+				return fmt.Errorf("qtree %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,215 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &QtreesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &QtreesDataSource{}
+
+func NewQtreesDataSource() datasource.DataSource {
+	return &QtreesDataSource{}
+}
+
+// QtreesDataSource defines the data source implementation.
+type QtreesDataSource struct {
+	client *ontap.Client
+}
+
+// QtreesDataSourceModel describes the data source data model.
+type QtreesDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	VolumeUUID    types.String `tfsdk:"volume_uuid"`
+	SVM           types.String `tfsdk:"svm"`
+	Name          types.String `tfsdk:"name"`
+	SecurityStyle types.String `tfsdk:"security_style"`
+
+	Qtrees []QtreeDataSourceModel `tfsdk:"qtrees"`
+}
+
+func (d *QtreesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_qtrees"
+}
+
+func (d *QtreesDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Qtrees of a volume or an SVM",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the data source, always `qtrees`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"volume_uuid": {
+				MarkdownDescription: "UUID of the volume to list qtrees from",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM to list qtrees from",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"name": {
+				MarkdownDescription: "Only return qtrees matching this name, `*` can be used as a wildcard, i.e. `home_*`",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"security_style": {
+				MarkdownDescription: "Only return qtrees with this security style",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("unix", "ntfs", "mixed", "unified"),
+				},
+			},
+			"qtrees": {
+				MarkdownDescription: "Matching qtrees",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"uuid": {
+						MarkdownDescription: "Qtree UUID, which is <VolumeUUID>/<QtreeID>",
+						Type:                types.StringType,
+						Computed:            true,
+					},
+					"id": {
+						Type:     types.Int64Type,
+						Computed: true,
+					},
+					"volume_uuid": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"path": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"security_style": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"unix_permissions": {
+						Type:     types.Int64Type,
+						Computed: true,
+					},
+					"qos_policy": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *QtreesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var volumeUUID types.String
+	var svm types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("volume_uuid"), &volumeUUID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("svm"), &svm)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if volumeUUID.Null && svm.Null {
+		resp.Diagnostics.AddError("Missing Attribute", "One of volume_uuid or svm is required")
+	}
+}
+
+func (d *QtreesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *QtreesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QtreesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.VolumeUUID.Null {
+		query.Set("volume.uuid", data.VolumeUUID.Value)
+	}
+	if !data.SVM.Null {
+		query.Set("svm.name", ontap.QueryLiteral(data.SVM.Value))
+	}
+	if !data.Name.Null {
+		query.Set("name", ontap.QueryWildcard(data.Name.Value))
+	}
+	if !data.SecurityStyle.Null {
+		query.Set("security_style", data.SecurityStyle.Value)
+	}
+
+	qtrees, err := d.client.WithContext(ctx).GetQtrees(query)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read qtrees, got error: %s", err))
+		return
+	}
+
+	data.ID = types.String{Value: "qtrees"}
+	data.Qtrees = []QtreeDataSourceModel{}
+	for i, qtree := range qtrees {
+		// Every volume has a default qtree with id 0 and no name
+		if qtree.Id == 0 {
+			continue
+		}
+
+		data.Qtrees = append(data.Qtrees, QtreeDataSourceModel{
+			UUID:           types.String{Value: qtree.UUID},
+			Id:             types.Int64{Value: qtree.Id},
+			VolumeUUID:     types.String{Value: qtree.VolumeUUID},
+			Name:           types.String{Value: qtree.Name},
+			Path:           stringModel(qtree.Path),
+			SecurityStyle:  stringModel(qtree.SecurityStyle),
+			UnixPermission: types.Int64{Value: qtree.UnixPermission},
+			QOSPolicy:      qtreeQOSPolicyModel(&qtrees[i]),
+		})
+	}
+
+	tflog.Trace(ctx, "read qtrees data source", map[string]interface{}{
+		"count": len(data.Qtrees),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
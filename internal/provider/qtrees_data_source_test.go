@@ -0,0 +1,146 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestAccQtreesDataSource(t *testing.T) {
+	// Qtrees are returned by pages of 2 records, that must all be read
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.PageSize = 2
+
+	svm_uuid := simulator.AddSVM("svm_qtrees")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_qtrees")
+	other_volume_uuid := simulator.AddVolume(svm_uuid, "vol_qtrees_other")
+
+	for i := 1; i <= 5; i++ {
+		simulator.AddQtree(volume_uuid, fmt.Sprintf("home_%d", i), "unix")
+	}
+	simulator.AddQtree(volume_uuid, "share", "ntfs")
+	simulator.AddQtree(other_volume_uuid, "home_other", "mixed")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Volume filtering, the default qtree isn't returned
+			{
+				Config: testAccQtreesDataSourceConfig(simulator, fmt.Sprintf(`volume_uuid = %q`, volume_uuid)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "6"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.name", "home_1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.id", "1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.uuid", volume_uuid+"/1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.path", "/vol_qtrees/home_1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.5.name", "share"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.5.security_style", "ntfs"),
+				),
+			},
+			// SVM filtering, across volumes
+			{
+				Config: testAccQtreesDataSourceConfig(simulator, `svm = "svm_qtrees"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "7"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.6.name", "home_other"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.6.volume_uuid", other_volume_uuid),
+				),
+			},
+			// Name and security style filtering
+			{
+				Config: testAccQtreesDataSourceConfig(simulator, `
+  svm  = "svm_qtrees"
+  name = "home_*"`),
+				Check: resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "6"),
+			},
+			{
+				Config: testAccQtreesDataSourceConfig(simulator, `
+  svm            = "svm_qtrees"
+  name           = "home_*"
+  security_style = "mixed"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.name", "home_other"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQtreesDataSourceEscaping(t *testing.T) {
+	// Only * is a wildcard in name, other ONTAP operators are matched
+	// literally
+	svm_uuid := testAccSimulator.AddSVM("svm_qtrees_escaping")
+	volume_uuid := testAccSimulator.AddVolume(svm_uuid, "vol_qtrees_escaping")
+
+	for _, name := range []string{"home", "share", "home|share", "home & co", "!home"} {
+		testAccSimulator.AddQtree(volume_uuid, name, "unix")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQtreesDataSourceConfig(testAccSimulator, fmt.Sprintf(`
+  volume_uuid = %q
+  name        = "home|share"`, volume_uuid)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.name", "home|share"),
+				),
+			},
+			{
+				Config: testAccQtreesDataSourceConfig(testAccSimulator, fmt.Sprintf(`
+  volume_uuid = %q
+  name        = "!home"`, volume_uuid)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.name", "!home"),
+				),
+			},
+			{
+				Config: testAccQtreesDataSourceConfig(testAccSimulator, fmt.Sprintf(`
+  volume_uuid = %q
+  name        = "home & *"`, volume_uuid)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.0.name", "home & co"),
+				),
+			},
+			{
+				Config: testAccQtreesDataSourceConfig(testAccSimulator, fmt.Sprintf(`
+  volume_uuid = %q
+  name        = "home*"`, volume_uuid)),
+				Check: resource.TestCheckResourceAttr("data.ontap_qtrees.test", "qtrees.#", "3"),
+			},
+		},
+	})
+}
+
+func TestAccQtreesDataSourceMissingScope(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccQtreesDataSourceConfig(testAccSimulator, `name = "home_*"`),
+				ExpectError: regexp.MustCompile("One of volume_uuid or svm is required"),
+			},
+		},
+	})
+}
+
+func testAccQtreesDataSourceConfig(simulator *ontaptest.Simulator, filters string) string {
+	return testAccSimulatorProviderConfig(simulator) + fmt.Sprintf(`
+data "ontap_qtrees" "test" {
+  %s
+}
+`, filters)
+}
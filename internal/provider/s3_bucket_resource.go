@@ -0,0 +1,384 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &S3BucketResource{}
+var _ resource.ResourceWithImportState = &S3BucketResource{}
+var _ resource.ResourceWithModifyPlan = &S3BucketResource{}
+
+func NewS3BucketResource() resource.Resource {
+	return &S3BucketResource{}
+}
+
+// S3BucketResource defines the resource implementation.
+type S3BucketResource struct {
+	client *ontap.Client
+}
+
+// S3BucketResourceModel describes the resource data model.
+type S3BucketResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	UUID    types.String `tfsdk:"uuid"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM              types.String                   `tfsdk:"svm"`
+	Name             types.String                   `tfsdk:"name"`
+	Size             types.Int64                    `tfsdk:"size"`
+	Comment          types.String                   `tfsdk:"comment"`
+	VersioningState  types.String                   `tfsdk:"versioning_state"`
+	PolicyStatements []S3BucketPolicyStatementModel `tfsdk:"policy_statements"`
+	LogicalUsedSize  types.Int64                    `tfsdk:"logical_used_size"`
+}
+
+type S3BucketPolicyStatementModel struct {
+	Sid        types.String   `tfsdk:"sid"`
+	Effect     types.String   `tfsdk:"effect"`
+	Actions    []types.String `tfsdk:"actions"`
+	Principals []types.String `tfsdk:"principals"`
+	Resources  []types.String `tfsdk:"resources"`
+}
+
+func (r *S3BucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket"
+}
+
+func (r *S3BucketResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "An S3 bucket",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the bucket, `<svm_uuid>/<uuid>`",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Bucket UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the bucket",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Bucket name",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"size": {
+				MarkdownDescription: "Size of the bucket in bytes",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "Bucket comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"versioning_state": {
+				MarkdownDescription: "Versioning of the bucket objects, `enabled`, `suspended` or `disabled`. Versioning can't be disabled once enabled",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("enabled", "suspended", "disabled"),
+				},
+			},
+			"policy_statements": {
+				MarkdownDescription: "Statements of the bucket access policy",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"sid": {
+						MarkdownDescription: "Statement identifier",
+						Type:                types.StringType,
+						Optional:            true,
+					},
+					"effect": {
+						MarkdownDescription: "Whether the statement grants access, `allow` or `deny`",
+						Type:                types.StringType,
+						Required:            true,
+						Validators: []tfsdk.AttributeValidator{
+							stringOneOf("allow", "deny"),
+						},
+					},
+					"actions": {
+						MarkdownDescription: "S3 actions, i.e. `GetObject` or `*`",
+						Type:                types.ListType{ElemType: types.StringType},
+						Required:            true,
+					},
+					"principals": {
+						MarkdownDescription: "S3 users or groups the statement applies to, all users when omitted",
+						Type:                types.ListType{ElemType: types.StringType},
+						Optional:            true,
+					},
+					"resources": {
+						MarkdownDescription: "Bucket and object names the statement applies to, i.e. `bucket1/*`",
+						Type:                types.ListType{ElemType: types.StringType},
+						Required:            true,
+					},
+				}),
+			},
+			"logical_used_size": {
+				MarkdownDescription: "Space used by the bucket objects in bytes",
+				Type:                types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *S3BucketResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Attributes of ontap_s3_bucket that require a recent ONTAP release
+var s3BucketAttributeVersions = []attributeVersion{
+	{Path: path.Root("policy_statements"), Generation: 9, Major: 8, Minor: 0},
+	{Path: path.Root("versioning_state"), Generation: 9, Major: 11, Minor: 1},
+}
+
+func (r *S3BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkAttributeVersions(ctx, r.client, req.Config, s3BucketAttributeVersions)...)
+}
+
+func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *S3BucketResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := data.toS3Bucket()
+	bucket.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+	bucket.Name = data.Name.Value
+
+	created_bucket, err := r.client.WithContext(ctx).CreateS3Bucket(&bucket)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create s3 bucket, got error: %s", err))
+		return
+	}
+
+	data.fromS3Bucket(created_bucket)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3BucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *S3BucketResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, err := r.client.WithContext(ctx).GetS3Bucket(data.SVMUUID.Value, data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read s3 bucket, got error: %s", err))
+		return
+	}
+
+	data.fromS3Bucket(bucket)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3BucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *S3BucketResourceModel
+	var state *S3BucketResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket := plan.toS3Bucket()
+	bucket.UUID = state.UUID.Value
+	bucket.SVM = &ontap.UUIDRef{UUID: state.SVMUUID.Value}
+
+	// Removing the statements from the configuration clears the policy
+	if plan.PolicyStatements == nil && state.PolicyStatements != nil {
+		bucket.Policy = &ontap.S3BucketPolicy{Statements: []ontap.S3BucketPolicyStatement{}}
+	}
+
+	updated_bucket, err := r.client.WithContext(ctx).UpdateS3Bucket(&bucket)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update s3 bucket, got error: %s", err))
+		return
+	}
+
+	plan.fromS3Bucket(updated_bucket)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *S3BucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *S3BucketResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteS3Bucket(data.SVMUUID.Value, data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete s3 bucket, got error: %s", err))
+		return
+	}
+}
+
+func (r *S3BucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	s := strings.Split(req.ID, "/")
+
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <svm_uuid>/<bucket_uuid>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("svm_uuid"), s[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), s[1])...)
+}
+
+// toS3Bucket returns the modifiable properties of the bucket
+func (data *S3BucketResourceModel) toS3Bucket() ontap.S3Bucket {
+	bucket := ontap.S3Bucket{}
+	if !data.Size.Null && !data.Size.Unknown {
+		bucket.Size = data.Size.Value
+	}
+	bucket.Comment = stringPointerValue(data.Comment)
+	if !data.VersioningState.Null && !data.VersioningState.Unknown {
+		bucket.VersioningState = data.VersioningState.Value
+	}
+
+	if data.PolicyStatements != nil {
+		bucket.Policy = &ontap.S3BucketPolicy{Statements: []ontap.S3BucketPolicyStatement{}}
+		for _, s := range data.PolicyStatements {
+			bucket.Policy.Statements = append(bucket.Policy.Statements, ontap.S3BucketPolicyStatement{
+				Sid:        s.Sid.Value,
+				Effect:     s.Effect.Value,
+				Actions:    stringListValues(s.Actions),
+				Principals: stringListValues(s.Principals),
+				Resources:  stringListValues(s.Resources),
+			})
+		}
+	}
+
+	return bucket
+}
+
+func (data *S3BucketResourceModel) fromS3Bucket(bucket *ontap.S3Bucket) {
+	data.UUID = types.String{Value: bucket.UUID}
+	if bucket.SVM != nil {
+		data.SVMUUID = types.String{Value: bucket.SVM.UUID}
+		data.SVM = types.String{Value: bucket.SVM.Name}
+	}
+	data.Name = types.String{Value: bucket.Name}
+	data.Size = types.Int64{Value: bucket.Size}
+	data.Comment = stringPointerModel(bucket.Comment)
+	data.VersioningState = stringModel(bucket.VersioningState)
+	data.LogicalUsedSize = types.Int64{Value: bucket.LogicalUsedSize}
+	data.ID = types.String{Value: data.SVMUUID.Value + "/" + data.UUID.Value}
+
+	if bucket.Policy == nil || len(bucket.Policy.Statements) == 0 {
+		if data.PolicyStatements != nil {
+			data.PolicyStatements = []S3BucketPolicyStatementModel{}
+		}
+		return
+	}
+
+	data.PolicyStatements = []S3BucketPolicyStatementModel{}
+	for _, s := range bucket.Policy.Statements {
+		statement := S3BucketPolicyStatementModel{
+			Sid:       stringModel(s.Sid),
+			Effect:    types.String{Value: s.Effect},
+			Actions:   stringListModel(s.Actions),
+			Resources: stringListModel(s.Resources),
+		}
+		if len(s.Principals) > 0 {
+			statement.Principals = stringListModel(s.Principals)
+		}
+		data.PolicyStatements = append(data.PolicyStatements, statement)
+	}
+}
@@ -0,0 +1,117 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccS3BucketResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_s3_bucket")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckS3BucketDestroyed("svm_s3_bucket", "bucket1"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the size and versioning are computed
+			{
+				Config: testAccS3BucketResourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_s3_bucket.test", "uuid"),
+					resource.TestCheckResourceAttrSet("ontap_s3_bucket.test", "svm_uuid"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "size", "858993459200"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "versioning_state", "disabled"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "logical_used_size", "0"),
+					resource.TestCheckNoResourceAttr("ontap_s3_bucket.test", "policy_statements.#"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccS3BucketResourceConfig(`
+  size             = 1073741824
+  comment          = "backups"
+  versioning_state = "enabled"
+
+  policy_statements = [
+    {
+      sid        = "ReadOnly"
+      effect     = "allow"
+      actions    = ["GetObject", "ListBucket"]
+      principals = ["reader"]
+      resources  = ["bucket1", "bucket1/*"]
+    },
+  ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "size", "1073741824"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "comment", "backups"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "versioning_state", "enabled"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "policy_statements.#", "1"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "policy_statements.0.sid", "ReadOnly"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "policy_statements.0.actions.1", "ListBucket"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "policy_statements.0.principals.0", "reader"),
+					func(s *terraform.State) error {
+						bucket := testAccSimulator.S3Bucket("svm_s3_bucket", "bucket1")
+						if bucket == nil || testAccRecordField(bucket, "policy.statements.#") != "1" {
+							return fmt.Errorf("expected bucket with a policy, got %v", bucket)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_s3_bucket.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Versioning can't be disabled once enabled
+			{
+				Config: testAccS3BucketResourceConfig(`
+  versioning_state = "disabled"`),
+				ExpectError: regexp.MustCompile(`can't\s+be\s+disabled\s+once\s+enabled`),
+			},
+			// The policy is removed with its statements
+			{
+				Config: testAccS3BucketResourceConfig(`
+  versioning_state  = "suspended"
+  policy_statements = []`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "versioning_state", "suspended"),
+					resource.TestCheckResourceAttr("ontap_s3_bucket.test", "policy_statements.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase, before the
+			// S3 service
+		},
+	})
+}
+
+func testAccS3BucketResourceConfig(attributes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_s3_service" "test" {
+  svm  = "svm_s3_bucket"
+  name = "s3.example.com"
+}
+
+resource "ontap_s3_bucket" "test" {
+  svm  = ontap_s3_service.test.svm
+  name = "bucket1"%s
+}
+`, attributes)
+}
+
+func testAccCheckS3BucketDestroyed(svm_name string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.S3Bucket(svm_name, name) != nil {
+			return fmt.Errorf("bucket %s of svm %s still exists", name, svm_name)
+		}
+		if testAccSimulator.S3Service(svm_name) != nil {
+			return fmt.Errorf("s3 service of svm %s still exists", svm_name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,310 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &S3ServiceResource{}
+var _ resource.ResourceWithImportState = &S3ServiceResource{}
+
+func NewS3ServiceResource() resource.Resource {
+	return &S3ServiceResource{}
+}
+
+// S3ServiceResource defines the resource implementation.
+type S3ServiceResource struct {
+	client *ontap.Client
+}
+
+// S3ServiceResourceModel describes the resource data model.
+type S3ServiceResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM          types.String `tfsdk:"svm"`
+	Name         types.String `tfsdk:"name"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	Comment      types.String `tfsdk:"comment"`
+	Certificate  types.String `tfsdk:"certificate"`
+	HTTPEnabled  types.Bool   `tfsdk:"http_enabled"`
+	HTTPSEnabled types.Bool   `tfsdk:"https_enabled"`
+	Port         types.Int64  `tfsdk:"port"`
+	SecurePort   types.Int64  `tfsdk:"secure_port"`
+}
+
+func (r *S3ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_service"
+}
+
+func (r *S3ServiceResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "The S3 server of an SVM",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the S3 service, the UUID of its SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Name of the S3 server, usually its FQDN",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"enabled": {
+				MarkdownDescription: "Whether the S3 server is running. Defaults to `true`",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "S3 server comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"certificate": {
+				MarkdownDescription: "Name of the certificate used for HTTPS",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"http_enabled": {
+				MarkdownDescription: "Whether the S3 server listens on HTTP",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"https_enabled": {
+				MarkdownDescription: "Whether the S3 server listens on HTTPS",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"port": {
+				MarkdownDescription: "HTTP port of the S3 server",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"secure_port": {
+				MarkdownDescription: "HTTPS port of the S3 server",
+				Type:                types.Int64Type,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *S3ServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *S3ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *S3ServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := data.toS3Service()
+	service.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+
+	created_service, err := r.client.WithContext(ctx).CreateS3Service(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create s3 service, got error: %s", err))
+		return
+	}
+
+	data.fromS3Service(created_service)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3ServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *S3ServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.WithContext(ctx).GetS3Service(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read s3 service, got error: %s", err))
+		return
+	}
+
+	data.fromS3Service(service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3ServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *S3ServiceResourceModel
+	var state *S3ServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service := plan.toS3Service()
+	service.SVM = &ontap.UUIDRef{UUID: state.SVMUUID.Value}
+
+	updated_service, err := r.client.WithContext(ctx).UpdateS3Service(&service)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update s3 service, got error: %s", err))
+		return
+	}
+
+	plan.fromS3Service(updated_service)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *S3ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *S3ServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteS3Service(data.SVMUUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete s3 service, got error: %s", err))
+		return
+	}
+}
+
+func (r *S3ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("svm_uuid"), req, resp)
+}
+
+func (data *S3ServiceResourceModel) toS3Service() ontap.S3Service {
+	service := ontap.S3Service{}
+	service.Name = data.Name.Value
+	service.Comment = stringPointerValue(data.Comment)
+	if !data.Enabled.Null && !data.Enabled.Unknown {
+		service.Enabled = &data.Enabled.Value
+	}
+	if !data.Certificate.Null && !data.Certificate.Unknown {
+		service.Certificate = &ontap.UUIDRef{Name: data.Certificate.Value}
+	}
+	if !data.HTTPEnabled.Null && !data.HTTPEnabled.Unknown {
+		service.IsHTTPEnabled = &data.HTTPEnabled.Value
+	}
+	if !data.HTTPSEnabled.Null && !data.HTTPSEnabled.Unknown {
+		service.IsHTTPSEnabled = &data.HTTPSEnabled.Value
+	}
+	if !data.Port.Null && !data.Port.Unknown {
+		service.Port = &data.Port.Value
+	}
+	if !data.SecurePort.Null && !data.SecurePort.Unknown {
+		service.SecurePort = &data.SecurePort.Value
+	}
+
+	return service
+}
+
+func (data *S3ServiceResourceModel) fromS3Service(service *ontap.S3Service) {
+	if service.SVM != nil {
+		data.SVMUUID = types.String{Value: service.SVM.UUID}
+		data.SVM = types.String{Value: service.SVM.Name}
+	}
+	data.Name = types.String{Value: service.Name}
+	data.ID = data.SVMUUID
+	data.Enabled = boolPointerModel(service.Enabled)
+	data.Comment = stringPointerModel(service.Comment)
+
+	data.Certificate = types.String{Null: true}
+	if service.Certificate != nil {
+		data.Certificate = stringModel(service.Certificate.Name)
+	}
+
+	data.HTTPEnabled = boolPointerModel(service.IsHTTPEnabled)
+	data.HTTPSEnabled = boolPointerModel(service.IsHTTPSEnabled)
+	data.Port = int64PointerModel(service.Port)
+	data.SecurePort = int64PointerModel(service.SecurePort)
+}
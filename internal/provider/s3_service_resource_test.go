@@ -0,0 +1,79 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccS3ServiceResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_s3")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckS3ServiceDestroyed("svm_s3"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the ports and protocols are computed
+			{
+				Config: testAccS3ServiceResourceConfig("s3.example.com", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_s3_service.test", "svm_uuid"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "name", "s3.example.com"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "http_enabled", "false"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "https_enabled", "true"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "port", "80"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "secure_port", "443"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccS3ServiceResourceConfig("s3-new.example.com", `
+  comment      = "object storage"
+  http_enabled = true
+  port         = 8080`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "name", "s3-new.example.com"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "comment", "object storage"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "http_enabled", "true"),
+					resource.TestCheckResourceAttr("ontap_s3_service.test", "port", "8080"),
+					func(s *terraform.State) error {
+						service := testAccSimulator.S3Service("svm_s3")
+						if service == nil || service["port"] != float64(8080) {
+							return fmt.Errorf("expected s3 service on port 8080, got %v", service)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_s3_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccS3ServiceResourceConfig(name string, attributes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_s3_service" "test" {
+  svm  = "svm_s3"
+  name = %q%s
+}
+`, name, attributes)
+}
+
+func testAccCheckS3ServiceDestroyed(svm_name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.S3Service(svm_name) != nil {
+			return fmt.Errorf("s3 service of svm %s still exists", svm_name)
+		}
+		return nil
+	}
+}
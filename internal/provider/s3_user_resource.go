@@ -0,0 +1,274 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &S3UserResource{}
+var _ resource.ResourceWithImportState = &S3UserResource{}
+
+func NewS3UserResource() resource.Resource {
+	return &S3UserResource{}
+}
+
+// S3UserResource defines the resource implementation.
+type S3UserResource struct {
+	client *ontap.Client
+}
+
+// S3UserResourceModel describes the resource data model.
+type S3UserResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	SVMUUID types.String `tfsdk:"svm_uuid"`
+
+	SVM       types.String `tfsdk:"svm"`
+	Name      types.String `tfsdk:"name"`
+	Comment   types.String `tfsdk:"comment"`
+	AccessKey types.String `tfsdk:"access_key"`
+	SecretKey types.String `tfsdk:"secret_key"`
+}
+
+func (r *S3UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_user"
+}
+
+func (r *S3UserResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "An S3 user. The keys are generated by ONTAP when the user is created, the secret key is only available in the state of the resource that created the user",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the user, `<svm_uuid>/<name>`",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm_uuid": {
+				MarkdownDescription: "UUID of the SVM",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM hosting the S3 server",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "User name",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "User comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"access_key": {
+				MarkdownDescription: "Access key of the user",
+				Type:                types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"secret_key": {
+				MarkdownDescription: "Secret key of the user",
+				Type:                types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *S3UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *S3UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *S3UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	svm_uuid, err := r.client.WithContext(ctx).GetSVMUUID(data.SVM.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read svm, got error: %s", err))
+		return
+	}
+
+	user := ontap.S3User{
+		Name:    data.Name.Value,
+		Comment: stringPointerValue(data.Comment),
+	}
+
+	created_user, err := r.client.WithContext(ctx).CreateS3User(svm_uuid, &user)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create s3 user, got error: %s", err))
+		return
+	}
+
+	data.SVMUUID = types.String{Value: svm_uuid}
+	data.fromS3User(created_user)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *S3UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.WithContext(ctx).GetS3User(data.SVMUUID.Value, data.Name.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read s3 user, got error: %s", err))
+		return
+	}
+
+	data.fromS3User(user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *S3UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *S3UserResourceModel
+	var state *S3UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user := ontap.S3User{
+		Name:    state.Name.Value,
+		Comment: stringPointerValue(plan.Comment),
+	}
+
+	updated_user, err := r.client.WithContext(ctx).UpdateS3User(state.SVMUUID.Value, &user)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update s3 user, got error: %s", err))
+		return
+	}
+
+	plan.fromS3User(updated_user)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *S3UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *S3UserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.WithContext(ctx).DeleteS3User(data.SVMUUID.Value, data.Name.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete s3 user, got error: %s", err))
+		return
+	}
+}
+
+func (r *S3UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	s := strings.Split(req.ID, "/")
+
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <svm_uuid>/<name>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("svm_uuid"), s[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), s[1])...)
+}
+
+// fromS3User updates the model from ONTAP, the secret key is kept from the
+// prior state as it is only returned on creation
+func (data *S3UserResourceModel) fromS3User(user *ontap.S3User) {
+	if user.SVM != nil {
+		data.SVMUUID = types.String{Value: user.SVM.UUID}
+		data.SVM = types.String{Value: user.SVM.Name}
+	}
+	data.Name = types.String{Value: user.Name}
+	data.Comment = stringPointerModel(user.Comment)
+	data.ID = types.String{Value: data.SVMUUID.Value + "/" + data.Name.Value}
+
+	if user.AccessKey != "" {
+		data.AccessKey = types.String{Value: user.AccessKey}
+	} else if data.AccessKey.Unknown {
+		data.AccessKey = types.String{Null: true}
+	}
+
+	if user.SecretKey != "" {
+		data.SecretKey = types.String{Value: user.SecretKey}
+	} else if data.SecretKey.Unknown {
+		data.SecretKey = types.String{Null: true}
+	}
+}
@@ -0,0 +1,80 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccS3UserResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_s3_user")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckS3UserDestroyed("svm_s3_user", "reader"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the keys are generated
+			{
+				Config: testAccS3UserResourceConfig("readers"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_s3_user.test", "svm_uuid"),
+					resource.TestCheckResourceAttr("ontap_s3_user.test", "svm", "svm_s3_user"),
+					resource.TestCheckResourceAttr("ontap_s3_user.test", "comment", "readers"),
+					resource.TestCheckResourceAttrSet("ontap_s3_user.test", "access_key"),
+					resource.TestCheckResourceAttrSet("ontap_s3_user.test", "secret_key"),
+				),
+			},
+			// Update and Read testing, the secret key is kept as ONTAP only
+			// returns it on creation
+			{
+				Config: testAccS3UserResourceConfig("backup readers"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_s3_user.test", "comment", "backup readers"),
+					resource.TestCheckResourceAttrSet("ontap_s3_user.test", "secret_key"),
+					func(s *terraform.State) error {
+						user := testAccSimulator.S3User("svm_s3_user", "reader")
+						if user == nil || user["comment"] != "backup readers" {
+							return fmt.Errorf("expected user with comment backup readers, got %v", user)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing, the secret key can't be imported
+			{
+				ResourceName:            "ontap_s3_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_key"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccS3UserResourceConfig(comment string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_s3_service" "test" {
+  svm  = "svm_s3_user"
+  name = "s3.example.com"
+}
+
+resource "ontap_s3_user" "test" {
+  svm     = ontap_s3_service.test.svm
+  name    = "reader"
+  comment = %q
+}
+`, comment)
+}
+
+func testAccCheckS3UserDestroyed(svm_name string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.S3User(svm_name, name) != nil {
+			return fmt.Errorf("s3 user %s of svm %s still exists", name, svm_name)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,382 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &SnapmirrorPolicyResource{}
+var _ resource.ResourceWithImportState = &SnapmirrorPolicyResource{}
+
+func NewSnapmirrorPolicyResource() resource.Resource {
+	return &SnapmirrorPolicyResource{}
+}
+
+// SnapmirrorPolicyResource defines the resource implementation.
+type SnapmirrorPolicyResource struct {
+	client *ontap.Client
+}
+
+// SnapmirrorPolicyResourceModel describes the resource data model.
+type SnapmirrorPolicyResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	Name                      types.String                        `tfsdk:"name"`
+	SVM                       types.String                        `tfsdk:"svm"`
+	Type                      types.String                        `tfsdk:"type"`
+	SyncType                  types.String                        `tfsdk:"sync_type"`
+	Comment                   types.String                        `tfsdk:"comment"`
+	Retention                 []SnapmirrorPolicyRuleResourceModel `tfsdk:"retention"`
+	TransferSchedule          types.String                        `tfsdk:"transfer_schedule"`
+	NetworkCompressionEnabled types.Bool                          `tfsdk:"network_compression_enabled"`
+	IdentityPreservation      types.String                        `tfsdk:"identity_preservation"`
+}
+
+type SnapmirrorPolicyRuleResourceModel struct {
+	Label            types.String `tfsdk:"label"`
+	Count            types.Int64  `tfsdk:"count"`
+	CreationSchedule types.String `tfsdk:"creation_schedule"`
+	Prefix           types.String `tfsdk:"prefix"`
+}
+
+func (r *SnapmirrorPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapmirror_policy"
+}
+
+func (r *SnapmirrorPolicyResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A SnapMirror policy",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the policy, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Policy UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Policy name",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the SVM owning the policy, cluster scoped when omitted",
+				Type:                types.StringType,
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"type": {
+				MarkdownDescription: "Policy type, `async` or `sync`. Defaults to `async`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("async", "sync"),
+				},
+			},
+			"sync_type": {
+				MarkdownDescription: "Synchronous policy type, `sync`, `strict_sync` or `automated_failover`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+					resource.RequiresReplace(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("sync", "strict_sync", "automated_failover"),
+				},
+			},
+			"comment": {
+				MarkdownDescription: "Policy comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"retention": {
+				MarkdownDescription: "Rules selecting the snapshots to transfer by SnapMirror label, and how many to keep on the destination",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"label": {
+						MarkdownDescription: "SnapMirror label of the snapshots",
+						Type:                types.StringType,
+						Required:            true,
+					},
+					"count": {
+						MarkdownDescription: "Number of snapshots to keep",
+						Type:                types.Int64Type,
+						Required:            true,
+					},
+					"creation_schedule": {
+						MarkdownDescription: "Schedule used to create snapshots on the destination",
+						Type:                types.StringType,
+						Optional:            true,
+					},
+					"prefix": {
+						MarkdownDescription: "Prefix of snapshots created on the destination",
+						Type:                types.StringType,
+						Optional:            true,
+						Computed:            true,
+					},
+				}),
+			},
+			"transfer_schedule": {
+				MarkdownDescription: "Name of the cluster schedule used for transfers",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"network_compression_enabled": {
+				MarkdownDescription: "Compress data on the network during transfers",
+				Type:                types.BoolType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"identity_preservation": {
+				MarkdownDescription: "Configuration replicated for SVM DR, `full`, `exclude_network_config` or `exclude_network_and_protocol_config`",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("full", "exclude_network_config", "exclude_network_and_protocol_config"),
+				},
+			},
+		},
+	}, nil
+}
+
+func (r *SnapmirrorPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SnapmirrorPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SnapmirrorPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := data.toSnapmirrorPolicy()
+
+	created_policy, err := r.client.WithContext(ctx).CreateSnapmirrorPolicy(&policy)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create snapmirror policy, got error: %s", err))
+		return
+	}
+
+	data.fromSnapmirrorPolicy(created_policy)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapmirrorPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SnapmirrorPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.WithContext(ctx).GetSnapmirrorPolicy(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read snapmirror policy, got error: %s", err))
+		return
+	}
+
+	data.fromSnapmirrorPolicy(policy)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapmirrorPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *SnapmirrorPolicyResourceModel
+	var state *SnapmirrorPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := plan.toSnapmirrorPolicy()
+	policy.UUID = state.UUID.Value
+
+	updated_policy, err := r.client.WithContext(ctx).UpdateSnapmirrorPolicy(&policy)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update snapmirror policy, got error: %s", err))
+		return
+	}
+
+	plan.fromSnapmirrorPolicy(updated_policy)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SnapmirrorPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SnapmirrorPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := ontap.SnapmirrorPolicy{}
+	policy.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteSnapmirrorPolicy(&policy)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete snapmirror policy, got error: %s", err))
+		return
+	}
+}
+
+func (r *SnapmirrorPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *SnapmirrorPolicyResourceModel) toSnapmirrorPolicy() ontap.SnapmirrorPolicy {
+	policy := ontap.SnapmirrorPolicy{}
+	policy.Name = data.Name.Value
+	if !data.SVM.Null {
+		policy.SVM = &ontap.UUIDRef{Name: data.SVM.Value}
+	}
+	if !data.Type.Unknown {
+		policy.Type = data.Type.Value
+	}
+	policy.SyncType = data.SyncType.Value
+	policy.Comment = stringPointerValue(data.Comment)
+
+	// Rules are only sent when managed, an empty list removing them
+	if data.Retention != nil {
+		policy.Retention = []ontap.SnapmirrorPolicyRule{}
+	}
+	for _, rule := range data.Retention {
+		policy_rule := ontap.SnapmirrorPolicyRule{
+			Label: rule.Label.Value,
+			Count: rule.Count.Value,
+		}
+		if !rule.CreationSchedule.Null {
+			policy_rule.CreationSchedule = &ontap.UUIDRef{Name: rule.CreationSchedule.Value}
+		}
+		if !rule.Prefix.Unknown {
+			policy_rule.Prefix = rule.Prefix.Value
+		}
+		policy.Retention = append(policy.Retention, policy_rule)
+	}
+
+	if !data.TransferSchedule.Null {
+		policy.TransferSchedule = &ontap.UUIDRef{Name: data.TransferSchedule.Value}
+	}
+	if !data.NetworkCompressionEnabled.Null && !data.NetworkCompressionEnabled.Unknown {
+		policy.NetworkCompressionEnabled = &data.NetworkCompressionEnabled.Value
+	}
+	if !data.IdentityPreservation.Unknown {
+		policy.IdentityPreservation = data.IdentityPreservation.Value
+	}
+
+	return policy
+}
+
+func (data *SnapmirrorPolicyResourceModel) fromSnapmirrorPolicy(policy *ontap.SnapmirrorPolicy) {
+	data.UUID = types.String{Value: policy.UUID}
+	data.ID = data.UUID
+	data.Name = types.String{Value: policy.Name}
+	data.SVM = types.String{Null: true}
+	if policy.SVM != nil {
+		data.SVM = stringModel(policy.SVM.Name)
+	}
+	data.Type = types.String{Value: policy.Type}
+	data.SyncType = stringModel(policy.SyncType)
+	data.Comment = stringPointerModel(policy.Comment)
+
+	// ONTAP adds default rules to some policy types, only track the rules
+	// when the configuration manages them
+	if data.Retention != nil {
+		data.Retention = []SnapmirrorPolicyRuleResourceModel{}
+		for _, rule := range policy.Retention {
+			rule_model := SnapmirrorPolicyRuleResourceModel{
+				Label:            types.String{Value: rule.Label},
+				Count:            types.Int64{Value: rule.Count},
+				CreationSchedule: types.String{Null: true},
+				Prefix:           stringModel(rule.Prefix),
+			}
+			if rule.CreationSchedule != nil {
+				rule_model.CreationSchedule = stringModel(rule.CreationSchedule.Name)
+			}
+			data.Retention = append(data.Retention, rule_model)
+		}
+	}
+
+	data.TransferSchedule = types.String{Null: true}
+	if policy.TransferSchedule != nil {
+		data.TransferSchedule = stringModel(policy.TransferSchedule.Name)
+	}
+
+	data.NetworkCompressionEnabled = boolPointerModel(policy.NetworkCompressionEnabled)
+	data.IdentityPreservation = stringModel(policy.IdentityPreservation)
+}
@@ -0,0 +1,142 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSnapmirrorPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSnapmirrorPolicyDestroyed("policy_acc"),
+		Steps: []resource.TestStep{
+			// Create and Read testing, the rules added by ONTAP aren't managed
+			{
+				Config: testAccSnapmirrorPolicyResourceConfig(`comment = "created by acceptance tests"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_snapmirror_policy.test", "uuid"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "type", "async"),
+					resource.TestCheckNoResourceAttr("ontap_snapmirror_policy.test", "sync_type"),
+					resource.TestCheckNoResourceAttr("ontap_snapmirror_policy.test", "retention"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "network_compression_enabled", "false"),
+				),
+			},
+			// Updating other attributes keeps the unmanaged rules
+			{
+				Config: testAccSnapmirrorPolicyResourceConfig(`
+  comment           = "updated"
+  transfer_schedule = ontap_cluster_schedule.test.name`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "comment", "updated"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "transfer_schedule", "sched_policy"),
+					testAccCheckSnapmirrorPolicyField("policy_acc", "retention.#", "1"),
+				),
+			},
+			// Managed rules replace the rules of the policy
+			{
+				Config: testAccSnapmirrorPolicyResourceConfig(`
+  retention = [
+    {
+      label             = "daily"
+      count             = 7
+      creation_schedule = ontap_cluster_schedule.test.name
+    },
+    {
+      label = "weekly"
+      count = 4
+    },
+  ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "retention.#", "2"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "retention.0.label", "daily"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "retention.0.creation_schedule", "sched_policy"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "retention.1.count", "4"),
+					resource.TestCheckNoResourceAttr("ontap_snapmirror_policy.test", "transfer_schedule"),
+					testAccCheckSnapmirrorPolicyField("policy_acc", "retention.#", "2"),
+				),
+			},
+			// An empty list removes every rule
+			{
+				Config: testAccSnapmirrorPolicyResourceConfig(`retention = []`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "retention.#", "0"),
+					testAccCheckSnapmirrorPolicyField("policy_acc", "retention.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSnapmirrorPolicyResourceSync(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSnapmirrorPolicyDestroyed("policy_sync_acc"),
+		Steps: []resource.TestStep{
+			// ONTAP sets sync_type when it isn't configured
+			{
+				Config: testAccProviderConfig() + `
+resource "ontap_snapmirror_policy" "test" {
+  name = "policy_sync_acc"
+  type = "sync"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapmirror_policy.test", "sync_type", "sync"),
+					resource.TestCheckNoResourceAttr("ontap_snapmirror_policy.test", "retention"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_snapmirror_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSnapmirrorPolicyResourceConfig(attributes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_cluster_schedule" "test" {
+  name     = "sched_policy"
+  type     = "interval"
+  interval = "P1D"
+}
+
+resource "ontap_snapmirror_policy" "test" {
+  name = "policy_acc"
+%s
+}
+`, attributes)
+}
+
+func testAccCheckSnapmirrorPolicyField(name string, field string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policy := testAccSimulator.SnapmirrorPolicy(name)
+		if policy == nil {
+			return fmt.Errorf("snapmirror policy %s doesn't exist", name)
+		}
+
+		if value := testAccRecordField(policy, field); value != expected {
+			return fmt.Errorf("expected %s of snapmirror policy %s to be %q, got %q", field, name, expected, value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSnapmirrorPolicyDestroyed(names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.SnapmirrorPolicy(name) != nil {
+				return fmt.Errorf("snapmirror policy %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,391 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &SnapmirrorRelationshipResource{}
+var _ resource.ResourceWithImportState = &SnapmirrorRelationshipResource{}
+
+func NewSnapmirrorRelationshipResource() resource.Resource {
+	return &SnapmirrorRelationshipResource{}
+}
+
+// SnapmirrorRelationshipResource defines the resource implementation.
+type SnapmirrorRelationshipResource struct {
+	client *ontap.Client
+}
+
+// SnapmirrorRelationshipResourceModel describes the resource data model.
+type SnapmirrorRelationshipResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SourcePath            types.String `tfsdk:"source_path"`
+	SourceCluster         types.String `tfsdk:"source_cluster"`
+	DestinationPath       types.String `tfsdk:"destination_path"`
+	Policy                types.String `tfsdk:"policy"`
+	TransferSchedule      types.String `tfsdk:"transfer_schedule"`
+	State                 types.String `tfsdk:"state"`
+	CurrentState          types.String `tfsdk:"current_state"`
+	WaitForInitialization types.Bool   `tfsdk:"wait_for_initialization"`
+	InitializationTimeout types.Int64  `tfsdk:"initialization_timeout"`
+	Healthy               types.Bool   `tfsdk:"healthy"`
+	LagTime               types.String `tfsdk:"lag_time"`
+}
+
+func (r *SnapmirrorRelationshipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapmirror_relationship"
+}
+
+func (r *SnapmirrorRelationshipResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A SnapMirror relationship, managed from the destination cluster",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the relationship, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Relationship UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"source_path": {
+				MarkdownDescription: "Source endpoint, i.e. `svm1:vol1`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"source_cluster": {
+				MarkdownDescription: "Name of the source cluster, when it is a peer of the destination cluster",
+				Type:                types.StringType,
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"destination_path": {
+				MarkdownDescription: "Destination endpoint, i.e. `svm1_dr:vol1_dst`",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"policy": {
+				MarkdownDescription: "Name of the SnapMirror policy, defaults to the cluster default policy",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"transfer_schedule": {
+				MarkdownDescription: "Name of the cluster schedule used for transfers",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"state": {
+				MarkdownDescription: "State of the relationship, `snapmirrored` (initializes, resyncs or resumes the relationship), `broken_off` (breaks it) or `paused` (quiesces it). Defaults to `snapmirrored`, see `current_state` for the state reported by ONTAP",
+				Type:                types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("snapmirrored", "broken_off", "paused"),
+				},
+			},
+			"current_state": {
+				MarkdownDescription: "State reported by ONTAP, i.e. `uninitialized` during the baseline transfer or `in_sync` for synchronous relationships",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"wait_for_initialization": {
+				MarkdownDescription: "Wait for the baseline transfer to complete when the relationship is initialized or resynchronized",
+				Type:                types.BoolType,
+				Optional:            true,
+			},
+			"initialization_timeout": {
+				MarkdownDescription: fmt.Sprintf("Maximum time in minutes to wait for the baseline transfer. Defaults to `%d`", int64(ontap.DefaultSnapmirrorInitializationTimeout/time.Minute)),
+				Type:                types.Int64Type,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					int64AtLeast(1),
+				},
+			},
+			"healthy": {
+				MarkdownDescription: "Whether the relationship is healthy",
+				Type:                types.BoolType,
+				Computed:            true,
+			},
+			"lag_time": {
+				MarkdownDescription: "Time since the exported snapshot was created, as an ISO-8601 duration",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *SnapmirrorRelationshipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SnapmirrorRelationshipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SnapmirrorRelationshipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Relationships are always initialized on creation, then moved to the
+	// requested state
+	relationship := data.toSnapmirrorRelationship()
+	relationship.State = "snapmirrored"
+
+	created_relationship, err := r.client.WithContext(ctx).CreateSnapmirrorRelationship(&relationship)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create snapmirror relationship, got error: %s", err))
+		return
+	}
+
+	// The baseline transfer has to be complete before the relationship can be
+	// broken or quiesced
+	state := data.State
+	if data.WaitForInitialization.Value || (!state.Unknown && state.Value != "snapmirrored") {
+		tflog.Debug(ctx, "waiting for snapmirror initialization", map[string]interface{}{
+			"uuid": created_relationship.UUID,
+		})
+
+		created_relationship, err = r.client.WithContext(ctx).WaitSnapmirrorRelationshipInitialized(created_relationship.UUID, data.initializationTimeout())
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to initialize snapmirror relationship, got error: %s", err))
+			return
+		}
+	}
+
+	if !state.Unknown && state.Value != "snapmirrored" {
+		created_relationship, err = r.client.WithContext(ctx).SetSnapmirrorRelationshipState(created_relationship.UUID, state.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set snapmirror relationship state, got error: %s", err))
+			return
+		}
+	}
+
+	data.fromSnapmirrorRelationship(created_relationship)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapmirrorRelationshipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SnapmirrorRelationshipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	relationship, err := r.client.WithContext(ctx).GetSnapmirrorRelationship(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read snapmirror relationship, got error: %s", err))
+		return
+	}
+
+	data.fromSnapmirrorRelationship(relationship)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapmirrorRelationshipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *SnapmirrorRelationshipResourceModel
+	var state *SnapmirrorRelationshipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	relationship := plan.toSnapmirrorRelationship()
+	relationship.UUID = state.UUID.Value
+
+	updated_relationship, err := r.client.WithContext(ctx).GetSnapmirrorRelationship(relationship.UUID)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read snapmirror relationship, got error: %s", err))
+		return
+	}
+
+	if !plan.Policy.Equal(state.Policy) || !plan.TransferSchedule.Equal(state.TransferSchedule) {
+		updated_relationship, err = r.client.WithContext(ctx).UpdateSnapmirrorRelationship(&relationship)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update snapmirror relationship, got error: %s", err))
+			return
+		}
+	}
+
+	if !plan.State.Unknown && plan.State.Value != snapmirrorRequestedState(updated_relationship.State) {
+		updated_relationship, err = r.client.WithContext(ctx).SetSnapmirrorRelationshipState(relationship.UUID, plan.State.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set snapmirror relationship state, got error: %s", err))
+			return
+		}
+
+		if plan.State.Value == "snapmirrored" && plan.WaitForInitialization.Value {
+			updated_relationship, err = r.client.WithContext(ctx).WaitSnapmirrorRelationshipInitialized(relationship.UUID, plan.initializationTimeout())
+
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to resynchronize snapmirror relationship, got error: %s", err))
+				return
+			}
+		}
+	}
+
+	plan.fromSnapmirrorRelationship(updated_relationship)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SnapmirrorRelationshipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SnapmirrorRelationshipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	relationship := ontap.SnapmirrorRelationship{}
+	relationship.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteSnapmirrorRelationship(&relationship)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete snapmirror relationship, got error: %s", err))
+		return
+	}
+}
+
+func (r *SnapmirrorRelationshipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *SnapmirrorRelationshipResourceModel) toSnapmirrorRelationship() ontap.SnapmirrorRelationship {
+	relationship := ontap.SnapmirrorRelationship{}
+	relationship.Source.Path = data.SourcePath.Value
+	if !data.SourceCluster.Null {
+		relationship.Source.Cluster = &ontap.UUIDRef{Name: data.SourceCluster.Value}
+	}
+	relationship.Destination.Path = data.DestinationPath.Value
+
+	if !data.Policy.Null && !data.Policy.Unknown {
+		relationship.Policy = &ontap.UUIDRef{Name: data.Policy.Value}
+	}
+	if !data.TransferSchedule.Null {
+		relationship.TransferSchedule = &ontap.UUIDRef{Name: data.TransferSchedule.Value}
+	}
+
+	return relationship
+}
+
+// initializationTimeout returns the time to wait for a baseline transfer
+func (data *SnapmirrorRelationshipResourceModel) initializationTimeout() time.Duration {
+	if data.InitializationTimeout.Null || data.InitializationTimeout.Unknown {
+		return ontap.DefaultSnapmirrorInitializationTimeout
+	}
+	return time.Duration(data.InitializationTimeout.Value) * time.Minute
+}
+
+func (data *SnapmirrorRelationshipResourceModel) fromSnapmirrorRelationship(relationship *ontap.SnapmirrorRelationship) {
+	data.UUID = types.String{Value: relationship.UUID}
+	data.ID = data.UUID
+	data.SourcePath = types.String{Value: relationship.Source.Path}
+	if relationship.Source.Cluster != nil && !data.SourceCluster.Null {
+		data.SourceCluster = types.String{Value: relationship.Source.Cluster.Name}
+	}
+	data.DestinationPath = types.String{Value: relationship.Destination.Path}
+
+	data.Policy = types.String{Null: true}
+	if relationship.Policy != nil {
+		data.Policy = types.String{Value: relationship.Policy.Name}
+	}
+
+	data.TransferSchedule = types.String{Null: true}
+	if relationship.TransferSchedule != nil {
+		data.TransferSchedule = stringModel(relationship.TransferSchedule.Name)
+	}
+
+	data.State = types.String{Value: snapmirrorRequestedState(relationship.State)}
+	data.CurrentState = types.String{Value: relationship.State}
+	data.Healthy = boolPointerModel(relationship.Healthy)
+	data.LagTime = stringModel(relationship.LagTime)
+}
+
+// snapmirrorRequestedState maps the state reported by ONTAP to the state that
+// was requested, a relationship being initialized or in sync is snapmirrored
+func snapmirrorRequestedState(state string) string {
+	switch state {
+	case "broken_off", "paused":
+		return state
+	}
+	return "snapmirrored"
+}
@@ -0,0 +1,99 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSnapmirrorRelationshipResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSnapmirrorRelationshipDestroyed("svm_dr:vol_dst"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSnapmirrorRelationshipResourceConfig(`
+  transfer_schedule       = ontap_cluster_schedule.test.name
+  wait_for_initialization = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_snapmirror_relationship.test", "uuid"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "policy", "MirrorAllSnapshots"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "transfer_schedule", "sched_snapmirror"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "state", "snapmirrored"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "current_state", "snapmirrored"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "healthy", "true"),
+				),
+			},
+			// Break the relationship and remove its schedule
+			{
+				Config: testAccSnapmirrorRelationshipResourceConfig(`
+  state = "broken_off"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "state", "broken_off"),
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "current_state", "broken_off"),
+					resource.TestCheckNoResourceAttr("ontap_snapmirror_relationship.test", "transfer_schedule"),
+					testAccCheckSnapmirrorRelationshipField("svm_dr:vol_dst", "transfer_schedule.name", ""),
+				),
+			},
+			// Resynchronize the relationship
+			{
+				Config: testAccSnapmirrorRelationshipResourceConfig(`
+  state = "snapmirrored"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapmirror_relationship.test", "state", "snapmirrored"),
+					testAccCheckSnapmirrorRelationshipField("svm_dr:vol_dst", "state", "snapmirrored"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_snapmirror_relationship.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSnapmirrorRelationshipResourceConfig(attributes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_cluster_schedule" "test" {
+  name     = "sched_snapmirror"
+  type     = "interval"
+  interval = "PT1H"
+}
+
+resource "ontap_snapmirror_relationship" "test" {
+  source_path      = "svm1:vol_src"
+  destination_path = "svm_dr:vol_dst"
+%s
+}
+`, attributes)
+}
+
+func testAccCheckSnapmirrorRelationshipField(destination_path string, field string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		relationship := testAccSimulator.SnapmirrorRelationship(destination_path)
+		if relationship == nil {
+			return fmt.Errorf("snapmirror relationship %s doesn't exist", destination_path)
+		}
+
+		if value := testAccRecordField(relationship, field); value != expected {
+			return fmt.Errorf("expected %s of snapmirror relationship %s to be %q, got %q", field, destination_path, expected, value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSnapmirrorRelationshipDestroyed(destination_path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.SnapmirrorRelationship(destination_path) != nil {
+			return fmt.Errorf("snapmirror relationship %s still exists", destination_path)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,279 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &SnapshotResource{}
+var _ resource.ResourceWithImportState = &SnapshotResource{}
+
+func NewSnapshotResource() resource.Resource {
+	return &SnapshotResource{}
+}
+
+// SnapshotResource defines the resource implementation.
+type SnapshotResource struct {
+	client *ontap.Client
+}
+
+// SnapshotResourceModel describes the resource data model.
+type SnapshotResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	UUID       types.String `tfsdk:"uuid"`
+	VolumeUUID types.String `tfsdk:"volume_uuid"`
+
+	Name            types.String `tfsdk:"name"`
+	Comment         types.String `tfsdk:"comment"`
+	ExpiryTime      types.String `tfsdk:"expiry_time"`
+	SnapmirrorLabel types.String `tfsdk:"snapmirror_label"`
+	CreateTime      types.String `tfsdk:"create_time"`
+	State           types.String `tfsdk:"state"`
+	Size            types.Int64  `tfsdk:"size"`
+}
+
+func (r *SnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot"
+}
+
+func (r *SnapshotResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A volume snapshot",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the snapshot, `<volume_uuid>/<uuid>`",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "Snapshot UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"volume_uuid": {
+				MarkdownDescription: "UUID of the volume the snapshot belongs to",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "Snapshot name, changing it renames the snapshot",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"comment": {
+				MarkdownDescription: "Snapshot comment",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"expiry_time": {
+				MarkdownDescription: "Time at which the snapshot is automatically deleted, as an RFC3339 timestamp",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringIsRFC3339(),
+				},
+			},
+			"snapmirror_label": {
+				MarkdownDescription: "Label used by SnapMirror policies to select the snapshot for transfer",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"create_time": {
+				MarkdownDescription: "Creation time of the snapshot",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"state": {
+				MarkdownDescription: "State of the snapshot, `valid`, `invalid` or `partial`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"size": {
+				MarkdownDescription: "Size of the snapshot in bytes",
+				Type:                types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *SnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SnapshotResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot := data.toSnapshot()
+
+	created_snapshot, err := r.client.WithContext(ctx).CreateSnapshot(&snapshot)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create snapshot, got error: %s", err))
+		return
+	}
+
+	data.fromSnapshot(created_snapshot)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SnapshotResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.client.WithContext(ctx).GetSnapshot(data.VolumeUUID.Value, data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read snapshot, got error: %s", err))
+		return
+	}
+
+	data.fromSnapshot(snapshot)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *SnapshotResourceModel
+	var state *SnapshotResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot := plan.toSnapshot()
+	snapshot.UUID = state.UUID.Value
+
+	updated_snapshot, err := r.client.WithContext(ctx).UpdateSnapshot(&snapshot)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update snapshot, got error: %s", err))
+		return
+	}
+
+	plan.fromSnapshot(updated_snapshot)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SnapshotResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot := ontap.Snapshot{}
+	snapshot.UUID = data.UUID.Value
+	snapshot.VolumeUUID = data.VolumeUUID.Value
+
+	err := r.client.WithContext(ctx).DeleteSnapshot(&snapshot)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete snapshot, got error: %s", err))
+		return
+	}
+}
+
+// Snapshots are imported with <VolumeUUID>/<SnapshotUUID>
+func (r *SnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	s := strings.Split(req.ID, "/")
+
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <volume_uuid>/<snapshot_uuid>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("volume_uuid"), s[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), s[1])...)
+}
+
+func (data *SnapshotResourceModel) toSnapshot() ontap.Snapshot {
+	snapshot := ontap.Snapshot{}
+	snapshot.VolumeUUID = data.VolumeUUID.Value
+	snapshot.Name = data.Name.Value
+	snapshot.Comment = stringPointerValue(data.Comment)
+	snapshot.ExpiryTime = data.ExpiryTime.Value
+	snapshot.SnapmirrorLabel = stringPointerValue(data.SnapmirrorLabel)
+
+	return snapshot
+}
+
+func (data *SnapshotResourceModel) fromSnapshot(snapshot *ontap.Snapshot) {
+	data.ID = types.String{Value: fmt.Sprintf("%s/%s", snapshot.VolumeUUID, snapshot.UUID)}
+	data.UUID = types.String{Value: snapshot.UUID}
+	data.VolumeUUID = types.String{Value: snapshot.VolumeUUID}
+	data.Name = types.String{Value: snapshot.Name}
+	data.Comment = stringPointerModel(snapshot.Comment)
+	data.ExpiryTime = timeModel(data.ExpiryTime, snapshot.ExpiryTime)
+	data.SnapmirrorLabel = stringPointerModel(snapshot.SnapmirrorLabel)
+	data.CreateTime = types.String{Value: snapshot.CreateTime}
+	data.State = types.String{Value: snapshot.State}
+	data.Size = types.Int64{Value: snapshot.Size}
+}
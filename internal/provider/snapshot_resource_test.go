@@ -0,0 +1,85 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSnapshotResource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_snapshot")
+	volume_uuid := testAccSimulator.AddVolume(svm_uuid, "vol_snapshot")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSnapshotDestroyed(volume_uuid, "snap_acc", "snap_acc_renamed"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSnapshotResourceConfig(volume_uuid, "snap_acc", "created by acceptance tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapshot.test", "name", "snap_acc"),
+					resource.TestCheckResourceAttrSet("ontap_snapshot.test", "uuid"),
+					resource.TestCheckResourceAttrSet("ontap_snapshot.test", "create_time"),
+					resource.TestCheckResourceAttr("ontap_snapshot.test", "state", "valid"),
+					// ONTAP returns the expiry time with a +00:00 offset
+					resource.TestCheckResourceAttr("ontap_snapshot.test", "expiry_time", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("ontap_snapshot.test", "snapmirror_label", "daily"),
+					testAccCheckSnapshotExists(volume_uuid, "snap_acc"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSnapshotResourceConfig(volume_uuid, "snap_acc_renamed", "renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_snapshot.test", "name", "snap_acc_renamed"),
+					resource.TestCheckResourceAttr("ontap_snapshot.test", "comment", "renamed"),
+					testAccCheckSnapshotExists(volume_uuid, "snap_acc_renamed"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ontap_snapshot.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"expiry_time"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSnapshotResourceConfig(volume_uuid string, name string, comment string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_snapshot" "test" {
+  volume_uuid      = %q
+  name             = %q
+  comment          = %q
+  expiry_time      = "2030-01-01T00:00:00Z"
+  snapmirror_label = "daily"
+}
+`, volume_uuid, name, comment)
+}
+
+func testAccCheckSnapshotExists(volume_uuid string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.Snapshot(volume_uuid, name) == nil {
+			return fmt.Errorf("snapshot %s doesn't exist", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSnapshotDestroyed(volume_uuid string, names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.Snapshot(volume_uuid, name) != nil {
+				return fmt.Errorf("snapshot %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,236 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &SnapshotsDataSource{}
+
+func NewSnapshotsDataSource() datasource.DataSource {
+	return &SnapshotsDataSource{}
+}
+
+// SnapshotsDataSource defines the data source implementation.
+type SnapshotsDataSource struct {
+	client *ontap.Client
+}
+
+// SnapshotsDataSourceModel describes the data source data model.
+type SnapshotsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	VolumeUUID    types.String `tfsdk:"volume_uuid"`
+	Name          types.String `tfsdk:"name"`
+	CreatedAfter  types.String `tfsdk:"created_after"`
+	CreatedBefore types.String `tfsdk:"created_before"`
+
+	Snapshots []SnapshotDataSourceModel `tfsdk:"snapshots"`
+}
+
+type SnapshotDataSourceModel struct {
+	UUID            types.String `tfsdk:"uuid"`
+	Name            types.String `tfsdk:"name"`
+	Comment         types.String `tfsdk:"comment"`
+	CreateTime      types.String `tfsdk:"create_time"`
+	ExpiryTime      types.String `tfsdk:"expiry_time"`
+	SnapmirrorLabel types.String `tfsdk:"snapmirror_label"`
+	State           types.String `tfsdk:"state"`
+	Size            types.Int64  `tfsdk:"size"`
+}
+
+func (d *SnapshotsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshots"
+}
+
+func (d *SnapshotsDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Snapshots of a volume",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the data source, same as volume_uuid",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"volume_uuid": {
+				MarkdownDescription: "UUID of the volume to list snapshots from",
+				Type:                types.StringType,
+				Required:            true,
+			},
+			"name": {
+				MarkdownDescription: "Only return snapshots matching this name, `*` can be used as a wildcard, i.e. `daily.*`",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"created_after": {
+				MarkdownDescription: "Only return snapshots created after this RFC3339 timestamp",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringIsRFC3339(),
+				},
+			},
+			"created_before": {
+				MarkdownDescription: "Only return snapshots created before this RFC3339 timestamp",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringIsRFC3339(),
+				},
+			},
+			"snapshots": {
+				MarkdownDescription: "Matching snapshots",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"uuid": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"name": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"comment": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"create_time": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"expiry_time": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"snapmirror_label": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"state": {
+						Type:     types.StringType,
+						Computed: true,
+					},
+					"size": {
+						Type:     types.Int64Type,
+						Computed: true,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (d *SnapshotsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SnapshotsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.Name.Null {
+		query.Set("name", ontap.QueryWildcard(data.Name.Value))
+	}
+
+	snapshots, err := d.client.WithContext(ctx).GetSnapshots(data.VolumeUUID.Value, query)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read snapshots, got error: %s", err))
+		return
+	}
+
+	// Creation time is filtered here rather than with an ONTAP query so that
+	// timestamps using different UTC offsets are compared as instants
+	var after, before time.Time
+	if !data.CreatedAfter.Null {
+		after, err = time.Parse(time.RFC3339, data.CreatedAfter.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("created_after"), "Invalid Attribute Value", fmt.Sprintf("Invalid RFC3339 timestamp: %s", err))
+		}
+	}
+	if !data.CreatedBefore.Null {
+		before, err = time.Parse(time.RFC3339, data.CreatedBefore.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("created_before"), "Invalid Attribute Value", fmt.Sprintf("Invalid RFC3339 timestamp: %s", err))
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.VolumeUUID
+	data.Snapshots = []SnapshotDataSourceModel{}
+	for _, snapshot := range snapshots {
+		if !data.CreatedAfter.Null || !data.CreatedBefore.Null {
+			created, err := time.Parse(time.RFC3339, snapshot.CreateTime)
+
+			// A snapshot without a valid creation time can't match a time filter
+			if err != nil {
+				continue
+			}
+			if !data.CreatedAfter.Null && !created.After(after) {
+				continue
+			}
+			if !data.CreatedBefore.Null && !created.Before(before) {
+				continue
+			}
+		}
+
+		data.Snapshots = append(data.Snapshots, SnapshotDataSourceModel{
+			UUID:            types.String{Value: snapshot.UUID},
+			Name:            types.String{Value: snapshot.Name},
+			Comment:         stringPointerModel(snapshot.Comment),
+			CreateTime:      types.String{Value: snapshot.CreateTime},
+			ExpiryTime:      stringModel(snapshot.ExpiryTime),
+			SnapmirrorLabel: stringPointerModel(snapshot.SnapmirrorLabel),
+			State:           types.String{Value: snapshot.State},
+			Size:            types.Int64{Value: snapshot.Size},
+		})
+	}
+
+	tflog.Trace(ctx, "read snapshots data source", map[string]interface{}{
+		"volume_uuid": data.VolumeUUID.Value,
+		"count":       len(data.Snapshots),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
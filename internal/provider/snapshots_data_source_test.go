@@ -0,0 +1,69 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSnapshotsDataSource(t *testing.T) {
+	svm_uuid := testAccSimulator.AddSVM("svm_snapshots")
+	volume_uuid := testAccSimulator.AddVolume(svm_uuid, "vol_snapshots")
+
+	testAccSimulator.AddSnapshot(volume_uuid, "daily.2022-01-01", "2022-01-01T00:10:00+00:00")
+	testAccSimulator.AddSnapshot(volume_uuid, "daily.2022-01-02", "2022-01-02T00:10:00+00:00")
+	testAccSimulator.AddSnapshot(volume_uuid, "daily.2022-01-03", "2022-01-03T00:10:00+00:00")
+	testAccSimulator.AddSnapshot(volume_uuid, "weekly.2022-01-02", "2022-01-02T00:20:00+00:00")
+	testAccSimulator.AddSnapshot(volume_uuid, "daily.undated", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Name filtering
+			{
+				Config: testAccSnapshotsDataSourceConfig(volume_uuid, `name = "daily.*"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_snapshots.test", "id", volume_uuid),
+					resource.TestCheckResourceAttr("data.ontap_snapshots.test", "snapshots.#", "4"),
+				),
+			},
+			// Creation time filtering compares instants across UTC offsets,
+			// and skips snapshots without a creation time
+			{
+				Config: testAccSnapshotsDataSourceConfig(volume_uuid, `
+  name           = "daily.*"
+  created_after  = "2022-01-02T01:00:00+02:00"
+  created_before = "2022-01-02T18:00:00-05:00"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_snapshots.test", "snapshots.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_snapshots.test", "snapshots.0.name", "daily.2022-01-02"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSnapshotsDataSourceInvalidTime(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSnapshotsDataSourceConfig("00000000-0000-4000-8000-000000000000", `created_after = "yesterday"`),
+				ExpectError: regexp.MustCompile("RFC3339"),
+			},
+		},
+	})
+}
+
+func testAccSnapshotsDataSourceConfig(volume_uuid string, filters string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+data "ontap_snapshots" "test" {
+  volume_uuid = %q
+  %s
+}
+`, volume_uuid, filters)
+}
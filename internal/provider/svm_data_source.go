@@ -282,232 +282,245 @@ func (d *SVMDataSource) Metadata(ctx context.Context, req datasource.MetadataReq
 }
 
 func (d *SVMDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	attributes := svmDataSourceAttributes()
+
+	attributes["uuid"] = tfsdk.Attribute{
+		Type:     types.StringType,
+		Required: true,
+	}
+
 	return tfsdk.Schema{
 			// This description is used by the documentation generator and the language server.
 			MarkdownDescription: "Example data source",
-			Attributes: map[string]tfsdk.Attribute{
-				"uuid": {
+			Attributes:          attributes,
+		},
+		nil
+}
+
+// svmDataSourceAttributes returns the attributes describing an SVM, shared by
+// the ontap_svm and ontap_svms data sources
+func svmDataSourceAttributes() map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"uuid": {
+			Type:     types.StringType,
+			Computed: true,
+		},
+		"aggregates": {
+			Computed: true,
+			Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+				"name": {
 					Type:     types.StringType,
 					Required: true,
 				},
-				"aggregates": {
-					Computed: true,
-					Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-						"name": {
-							Type:     types.StringType,
-							Required: true,
-						},
-						"uuid": {
-							Type:     types.StringType,
-							Required: true,
-						},
-					}),
-				},
-				"aggregates_delegated": {
-					Type:     types.BoolType,
-					Optional: true,
-				},
-				"certificate": {
+				"uuid": {
 					Type:     types.StringType,
-					Optional: true,
+					Required: true,
 				},
-				"cifs": {
-					Optional: true,
-					Type: types.ObjectType{
+			}),
+		},
+		"aggregates_delegated": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"certificate": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+		"cifs": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"ad_domain": types.ObjectType{
 						AttrTypes: map[string]attr.Type{
-							"ad_domain": types.ObjectType{
-								AttrTypes: map[string]attr.Type{
-									"fqdn":                types.StringType,
-									"organizational_unit": types.StringType,
-								},
-							},
-							"enabled": types.BoolType,
-							"name":    types.StringType,
+							"fqdn":                types.StringType,
+							"organizational_unit": types.StringType,
 						},
 					},
+					"enabled": types.BoolType,
+					"name":    types.StringType,
 				},
-				"comment": {
-					Type:     types.StringType,
-					Optional: true,
+			},
+		},
+		"comment": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+		"dns": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"domains": types.ListType{ElemType: types.StringType},
+					"servers": types.ListType{ElemType: types.StringType},
 				},
-				"dns": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"domains": types.ListType{ElemType: types.StringType},
-							"servers": types.ListType{ElemType: types.StringType},
-						},
+			},
+		},
+		"fc_interfaces": {
+			Optional: true,
+			Type: types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"data_protocol": types.StringType,
+						"name":          types.StringType,
+						"uuid":          types.StringType,
 					},
 				},
-				"fc_interfaces": {
-					Optional: true,
-					Type: types.ListType{
-						ElemType: types.ObjectType{
+			},
+		},
+		"fcp": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"ip_interfaces": {
+			Optional: true,
+			Type: types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"ip": types.ObjectType{
 							AttrTypes: map[string]attr.Type{
-								"data_protocol": types.StringType,
-								"name":          types.StringType,
-								"uuid":          types.StringType,
+								"address": types.StringType,
+								"netmask": types.StringType,
 							},
 						},
+						"name":           types.StringType,
+						"service_policy": types.StringType,
+						"services": types.ListType{
+							ElemType: types.StringType,
+						},
+						"uuid": types.StringType,
 					},
 				},
-				"fcp": {
-					Type:     types.BoolType,
-					Optional: true,
+			},
+		},
+		"ipspace": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"name": types.StringType,
+					"uuid": types.StringType,
 				},
-				"ip_interfaces": {
-					Optional: true,
-					Type: types.ListType{
-						ElemType: types.ObjectType{
-							AttrTypes: map[string]attr.Type{
-								"ip": types.ObjectType{
-									AttrTypes: map[string]attr.Type{
-										"address": types.StringType,
-										"netmask": types.StringType,
-									},
-								},
-								"name":           types.StringType,
-								"service_policy": types.StringType,
-								"services": types.ListType{
-									ElemType: types.StringType,
-								},
-								"uuid": types.StringType,
-							},
-						},
+			},
+		},
+		"iscsi": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"language": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+		"ldap": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"ad_domain": types.StringType,
+					"base_dn":   types.StringType,
+					"bind_dn":   types.StringType,
+					"enabled":   types.BoolType,
+					"servers": types.ListType{
+						ElemType: types.StringType,
 					},
 				},
-				"ipspace": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"name": types.StringType,
-							"uuid": types.StringType,
-						},
+			},
+		},
+		"name": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+		"nfs": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"nis": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"domain":  types.StringType,
+					"enabled": types.BoolType,
+					"servers": types.ListType{
+						ElemType: types.StringType,
 					},
 				},
-				"iscsi": {
-					Type:     types.BoolType,
-					Optional: true,
-				},
-				"language": {
-					Type:     types.StringType,
-					Optional: true,
-				},
-				"ldap": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"ad_domain": types.StringType,
-							"base_dn":   types.StringType,
-							"bind_dn":   types.StringType,
-							"enabled":   types.BoolType,
-							"servers": types.ListType{
-								ElemType: types.StringType,
-							},
-						},
+			},
+		},
+		"nvme": {
+			Type:     types.BoolType,
+			Optional: true,
+		},
+		"nsswitch": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"group": types.ListType{
+						ElemType: types.StringType,
 					},
-				},
-				"name": {
-					Type:     types.StringType,
-					Optional: true,
-				},
-				"nfs": {
-					Type:     types.BoolType,
-					Optional: true,
-				},
-				"nis": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"domain":  types.StringType,
-							"enabled": types.BoolType,
-							"servers": types.ListType{
-								ElemType: types.StringType,
-							},
-						},
+					"hosts": types.ListType{
+						ElemType: types.StringType,
 					},
-				},
-				"nvme": {
-					Type:     types.BoolType,
-					Optional: true,
-				},
-				"nsswitch": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"group": types.ListType{
-								ElemType: types.StringType,
-							},
-							"hosts": types.ListType{
-								ElemType: types.StringType,
-							},
-							"namemap": types.ListType{
-								ElemType: types.StringType,
-							},
-							"netgroup": types.ListType{
-								ElemType: types.StringType,
-							},
-							"passwd": types.ListType{
-								ElemType: types.StringType,
-							},
-						},
+					"namemap": types.ListType{
+						ElemType: types.StringType,
 					},
-				},
-				"routes": {
-					Optional: true,
-					Type: types.ListType{
-						ElemType: types.ObjectType{
-							AttrTypes: map[string]attr.Type{
-								"destination": types.ObjectType{
-									AttrTypes: map[string]attr.Type{
-										"address": types.StringType,
-										"family":  types.StringType,
-										"netmask": types.StringType,
-									},
-								},
-								"gateway": types.StringType,
-							},
-						},
+					"netgroup": types.ListType{
+						ElemType: types.StringType,
 					},
-				},
-				"s3": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"enabled": types.BoolType,
-							"name":    types.StringType,
-						},
+					"passwd": types.ListType{
+						ElemType: types.StringType,
 					},
 				},
-				"snapmirror": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"is_protected":            types.BoolType,
-							"protected_volumes_count": types.Int64Type,
+			},
+		},
+		"routes": {
+			Optional: true,
+			Type: types.ListType{
+				ElemType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"destination": types.ObjectType{
+							AttrTypes: map[string]attr.Type{
+								"address": types.StringType,
+								"family":  types.StringType,
+								"netmask": types.StringType,
+							},
 						},
+						"gateway": types.StringType,
 					},
 				},
-				"snapshot_policy": {
-					Optional: true,
-					Type: types.ObjectType{
-						AttrTypes: map[string]attr.Type{
-							"name": types.StringType,
-							"uuid": types.StringType,
-						},
-					},
+			},
+		},
+		"s3": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"enabled": types.BoolType,
+					"name":    types.StringType,
 				},
-				"state": {
-					Type:     types.StringType,
-					Optional: true,
+			},
+		},
+		"snapmirror": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"is_protected":            types.BoolType,
+					"protected_volumes_count": types.Int64Type,
 				},
-				"subtype": {
-					Type:     types.StringType,
-					Optional: true,
+			},
+		},
+		"snapshot_policy": {
+			Optional: true,
+			Type: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"name": types.StringType,
+					"uuid": types.StringType,
 				},
 			},
 		},
-		nil
+		"state": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+		"subtype": {
+			Type:     types.StringType,
+			Optional: true,
+		},
+	}
 }
 
 func (d *SVMDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -541,12 +554,24 @@ func (d *SVMDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
-	SVM, err := d.client.GetSVM(&data.UUID.Value, nil)
+	SVM, err := d.client.WithContext(ctx).GetSVM(&data.UUID.Value, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SVM, got error: %s", err))
 		return
 	}
 
+	data.fromSVM(SVM)
+
+	// Write logs using the tflog package
+	// Documentation: https://terraform.io/plugin/log
+	tflog.Trace(ctx, "read a data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fromSVM updates the model with an SVM returned by ONTAP
+func (data *SVMDataSourceModel) fromSVM(SVM *ontap.SVM) {
 	// UUID
 	data.UUID = types.String{Value: *SVM.UUID}
 
@@ -650,7 +675,9 @@ func (d *SVMDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 	}
 
 	// FCP
-	data.FCP = types.Bool{Value: SVM.FCP.Enabled}
+	if SVM.FCP != nil {
+		data.FCP = types.Bool{Value: SVM.FCP.Enabled}
+	}
 
 	// Name
 	data.Name = types.String{Value: SVM.Name}
@@ -688,63 +715,75 @@ func (d *SVMDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 	}
 
 	// ISCSI
-	data.ISCSI = types.Bool{Value: SVM.ISCSI.Enabled}
+	if SVM.ISCSI != nil {
+		data.ISCSI = types.Bool{Value: SVM.ISCSI.Enabled}
+	}
 
 	// Language
 	data.Language = types.String{Value: SVM.Language}
 
 	// LDAP
-	ldap := NewLDAPDataSourceModel()
-	if SVM.LDAP.ADDomain != nil {
-		ldap.ADDomain = types.String{Value: *SVM.LDAP.ADDomain}
-	}
-	if SVM.LDAP.BaseDN != nil {
-		ldap.BaseDN = types.String{Value: *SVM.LDAP.BaseDN}
-	}
-	if SVM.LDAP.BindDN != nil {
-		ldap.BindDN = types.String{Value: *SVM.LDAP.BindDN}
-	}
-	ldap.Enabled = types.Bool{Value: SVM.LDAP.Enabled}
-	data.LDAP = &ldap
+	if SVM.LDAP != nil {
+		ldap := NewLDAPDataSourceModel()
+		if SVM.LDAP.ADDomain != nil {
+			ldap.ADDomain = types.String{Value: *SVM.LDAP.ADDomain}
+		}
+		if SVM.LDAP.BaseDN != nil {
+			ldap.BaseDN = types.String{Value: *SVM.LDAP.BaseDN}
+		}
+		if SVM.LDAP.BindDN != nil {
+			ldap.BindDN = types.String{Value: *SVM.LDAP.BindDN}
+		}
+		ldap.Enabled = types.Bool{Value: SVM.LDAP.Enabled}
+		data.LDAP = &ldap
 
-	for _, s := range SVM.LDAP.Servers {
-		data.LDAP.Servers = append(data.LDAP.Servers, types.String{Value: s})
+		for _, s := range SVM.LDAP.Servers {
+			data.LDAP.Servers = append(data.LDAP.Servers, types.String{Value: s})
+		}
 	}
 
 	// NFS
-	data.NFS = types.Bool{Value: SVM.NFS.Enabled}
+	if SVM.NFS != nil {
+		data.NFS = types.Bool{Value: SVM.NFS.Enabled}
+	}
 
 	// NIS
-	nis := NewNISDataSourceModel()
-	if SVM.NIS.Domain != nil {
-		nis.Domain = types.String{Value: *SVM.NIS.Domain}
-	}
-	data.NIS = &nis
-	for _, s := range SVM.LDAP.Servers {
-		data.NIS.Servers = append(data.NIS.Servers, types.String{Value: s})
+	if SVM.NIS != nil {
+		nis := NewNISDataSourceModel()
+		if SVM.NIS.Domain != nil {
+			nis.Domain = types.String{Value: *SVM.NIS.Domain}
+		}
+		data.NIS = &nis
+		for _, s := range SVM.NIS.Servers {
+			data.NIS.Servers = append(data.NIS.Servers, types.String{Value: s})
+		}
 	}
 
 	// NSSwitch
-	nsswitch := NSSwitchDataSourceModel{}
-	for _, a := range SVM.NSSwitch.Group {
-		nsswitch.Group = append(nsswitch.Group, types.String{Value: a})
-	}
-	for _, a := range SVM.NSSwitch.Hosts {
-		nsswitch.Hosts = append(nsswitch.Hosts, types.String{Value: a})
-	}
-	for _, a := range SVM.NSSwitch.Namemap {
-		nsswitch.Namemap = append(nsswitch.Namemap, types.String{Value: a})
-	}
-	for _, a := range SVM.NSSwitch.Netgroup {
-		nsswitch.Netgroup = append(nsswitch.Netgroup, types.String{Value: a})
-	}
-	for _, a := range SVM.NSSwitch.Passwd {
-		nsswitch.Passwd = append(nsswitch.Passwd, types.String{Value: a})
+	if SVM.NSSwitch != nil {
+		nsswitch := NSSwitchDataSourceModel{}
+		for _, a := range SVM.NSSwitch.Group {
+			nsswitch.Group = append(nsswitch.Group, types.String{Value: a})
+		}
+		for _, a := range SVM.NSSwitch.Hosts {
+			nsswitch.Hosts = append(nsswitch.Hosts, types.String{Value: a})
+		}
+		for _, a := range SVM.NSSwitch.Namemap {
+			nsswitch.Namemap = append(nsswitch.Namemap, types.String{Value: a})
+		}
+		for _, a := range SVM.NSSwitch.Netgroup {
+			nsswitch.Netgroup = append(nsswitch.Netgroup, types.String{Value: a})
+		}
+		for _, a := range SVM.NSSwitch.Passwd {
+			nsswitch.Passwd = append(nsswitch.Passwd, types.String{Value: a})
+		}
+		data.NSSwitch = &nsswitch
 	}
-	data.NSSwitch = &nsswitch
 
 	// NVME
-	data.NVME = types.Bool{Value: SVM.NVME.Enabled}
+	if SVM.NVME != nil {
+		data.NVME = types.Bool{Value: SVM.NVME.Enabled}
+	}
 
 	// Routes
 	routes := []RouteDataSourceModel{}
@@ -794,11 +833,4 @@ func (d *SVMDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	// Subtype
 	data.Subtype = types.String{Value: SVM.Subtype}
-
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "read a data source")
-
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
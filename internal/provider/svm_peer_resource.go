@@ -0,0 +1,288 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &SVMPeerResource{}
+var _ resource.ResourceWithImportState = &SVMPeerResource{}
+
+func NewSVMPeerResource() resource.Resource {
+	return &SVMPeerResource{}
+}
+
+// SVMPeerResource defines the resource implementation.
+type SVMPeerResource struct {
+	client *ontap.Client
+}
+
+// SVMPeerResourceModel describes the resource data model.
+type SVMPeerResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	UUID types.String `tfsdk:"uuid"`
+
+	SVM          types.String   `tfsdk:"svm"`
+	PeerSVM      types.String   `tfsdk:"peer_svm"`
+	PeerCluster  types.String   `tfsdk:"peer_cluster"`
+	Applications []types.String `tfsdk:"applications"`
+	Accept       types.Bool     `tfsdk:"accept"`
+	State        types.String   `tfsdk:"state"`
+}
+
+func (r *SVMPeerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_svm_peer"
+}
+
+func (r *SVMPeerResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "An SVM peer relationship. Across clusters, the relationship is created on one cluster and accepted on the remote cluster with `accept`, through a second provider alias.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the SVM peer, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"uuid": {
+				MarkdownDescription: "SVM peer UUID",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
+			"svm": {
+				MarkdownDescription: "Name of the local SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"peer_svm": {
+				MarkdownDescription: "Name of the peer SVM",
+				Type:                types.StringType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"peer_cluster": {
+				MarkdownDescription: "Name of the peer cluster, omitted when both SVMs are on the same cluster",
+				Type:                types.StringType,
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"applications": {
+				MarkdownDescription: "Applications allowed to use the peering, `snapmirror`, `file_copy`, `lun_copy` or `flexcache`",
+				Type:                types.ListType{ElemType: types.StringType},
+				Required:            true,
+			},
+			"accept": {
+				MarkdownDescription: "Accept a pending relationship created from the peer cluster instead of creating one. The relationship is left untouched when an accepting resource is destroyed, as it is owned by the resource that created it.",
+				Type:                types.BoolType,
+				Optional:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.RequiresReplace(),
+				},
+			},
+			"state": {
+				MarkdownDescription: "State of the relationship, i.e. `peered` or `pending`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+		},
+	}, nil
+}
+
+func (r *SVMPeerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SVMPeerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SVMPeerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var created_peer *ontap.SVMPeer
+	var err error
+
+	if data.Accept.Value {
+		pending_peer, err := r.client.WithContext(ctx).GetSVMPeerByName(data.SVM.Value, data.PeerSVM.Value)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find pending svm peer, got error: %s", err))
+			return
+		}
+
+		created_peer, err = r.client.WithContext(ctx).UpdateSVMPeer(&ontap.SVMPeer{
+			UUID:         pending_peer.UUID,
+			Applications: stringListValues(data.Applications),
+			State:        "peered",
+		})
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to accept svm peer, got error: %s", err))
+			return
+		}
+	} else {
+		peer := ontap.SVMPeer{
+			SVM: &ontap.UUIDRef{Name: data.SVM.Value},
+			Peer: &ontap.SVMPeerPeer{
+				SVM: ontap.UUIDRef{Name: data.PeerSVM.Value},
+			},
+			Applications: stringListValues(data.Applications),
+		}
+		if !data.PeerCluster.Null {
+			peer.Peer.Cluster = &ontap.UUIDRef{Name: data.PeerCluster.Value}
+		}
+
+		created_peer, err = r.client.WithContext(ctx).CreateSVMPeer(&peer)
+
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create svm peer, got error: %s", err))
+			return
+		}
+	}
+
+	data.fromSVMPeer(created_peer)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SVMPeerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SVMPeerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peer, err := r.client.WithContext(ctx).GetSVMPeer(data.UUID.Value)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read svm peer, got error: %s", err))
+		return
+	}
+
+	data.fromSVMPeer(peer)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SVMPeerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *SVMPeerResourceModel
+	var state *SVMPeerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peer := ontap.SVMPeer{
+		UUID:         state.UUID.Value,
+		Applications: stringListValues(plan.Applications),
+	}
+
+	updated_peer, err := r.client.WithContext(ctx).UpdateSVMPeer(&peer)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update svm peer, got error: %s", err))
+		return
+	}
+
+	plan.fromSVMPeer(updated_peer)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SVMPeerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SVMPeerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting the relationship on the cluster that created it also removes
+	// it from the accepting cluster
+	if data.Accept.Value {
+		return
+	}
+
+	peer := ontap.SVMPeer{}
+	peer.UUID = data.UUID.Value
+
+	err := r.client.WithContext(ctx).DeleteSVMPeer(&peer)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete svm peer, got error: %s", err))
+		return
+	}
+}
+
+func (r *SVMPeerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+}
+
+func (data *SVMPeerResourceModel) fromSVMPeer(peer *ontap.SVMPeer) {
+	data.UUID = types.String{Value: peer.UUID}
+	data.ID = data.UUID
+	if peer.SVM != nil {
+		data.SVM = types.String{Value: peer.SVM.Name}
+	}
+	if peer.Peer != nil {
+		data.PeerSVM = types.String{Value: peer.Peer.SVM.Name}
+		if peer.Peer.Cluster != nil && !data.PeerCluster.Null {
+			data.PeerCluster = types.String{Value: peer.Peer.Cluster.Name}
+		}
+	}
+	data.Applications = stringListModel(peer.Applications)
+	data.State = types.String{Value: peer.State}
+}
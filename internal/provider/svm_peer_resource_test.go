@@ -0,0 +1,95 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSVMPeerResource(t *testing.T) {
+	testAccSimulator.AddSVM("svm_peer_src")
+	testAccSimulator.AddSVM("svm_peer_dst")
+	testAccSimulator.AddSVM("svm_peer_accept")
+	testAccSimulator.AddSVMPeer("svm_peer_accept", "svm_remote", "cluster_remote")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckSVMPeerDestroyed("svm_peer_src", "svm_peer_dst"),
+			// The accepted relationship is owned by the peer cluster
+			testAccCheckSVMPeerState("svm_peer_accept", "svm_remote", "peered"),
+		),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSVMPeerResourceConfig(`["snapmirror"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ontap_svm_peer.local", "uuid"),
+					resource.TestCheckResourceAttr("ontap_svm_peer.local", "state", "peered"),
+					resource.TestCheckResourceAttr("ontap_svm_peer.local", "applications.#", "1"),
+					resource.TestCheckResourceAttr("ontap_svm_peer.accept", "state", "peered"),
+					resource.TestCheckResourceAttr("ontap_svm_peer.accept", "peer_cluster", "cluster_remote"),
+					testAccCheckSVMPeerState("svm_peer_accept", "svm_remote", "peered"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSVMPeerResourceConfig(`["snapmirror", "flexcache"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_svm_peer.local", "applications.#", "2"),
+					resource.TestCheckResourceAttr("ontap_svm_peer.accept", "applications.1", "flexcache"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ontap_svm_peer.local",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSVMPeerResourceConfig(applications string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_svm_peer" "local" {
+  svm          = "svm_peer_src"
+  peer_svm     = "svm_peer_dst"
+  applications = %[1]s
+}
+
+resource "ontap_svm_peer" "accept" {
+  svm          = "svm_peer_accept"
+  peer_svm     = "svm_remote"
+  peer_cluster = "cluster_remote"
+  applications = %[1]s
+  accept       = true
+}
+`, applications)
+}
+
+func testAccCheckSVMPeerState(svm_name string, peer_svm_name string, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		peer := testAccSimulator.SVMPeer(svm_name, peer_svm_name)
+		if peer == nil {
+			return fmt.Errorf("svm peer %s:%s doesn't exist", svm_name, peer_svm_name)
+		}
+		if peer["state"] != state {
+			return fmt.Errorf("expected svm peer %s:%s to be %s, got %v", svm_name, peer_svm_name, state, peer["state"])
+		}
+		return nil
+	}
+}
+
+func testAccCheckSVMPeerDestroyed(svm_name string, peer_svm_name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.SVMPeer(svm_name, peer_svm_name) != nil {
+			return fmt.Errorf("svm peer %s:%s still exists", svm_name, peer_svm_name)
+		}
+		return nil
+	}
+}
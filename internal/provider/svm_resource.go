@@ -29,6 +29,7 @@ type SVMResource struct {
 
 // SVMResourceModel describes the resource data model.
 type SVMResourceModel struct {
+	ID   types.String `tfsdk:"id"`
 	UUID types.String `tfsdk:"uuid"`
 	// Aggregates          []AggregateResourceModel     `tfsdk:"aggregates"`
 	// AggregatesDelegated types.Bool                     `tfsdk:"aggregates_delegated"`
@@ -152,6 +153,14 @@ func (r *SVMResource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnos
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "An ONTAP SVM",
 		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the SVM, same as uuid",
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					resource.UseStateForUnknown(),
+				},
+			},
 			"uuid": {
 				Type:     types.StringType,
 				Optional: true,
@@ -446,7 +455,7 @@ func (r *SVMResource) Create(ctx context.Context, req resource.CreateRequest, re
 		svm.DNS = &dns
 	}
 
-	created_svm, err := r.client.CreateSVM(&svm)
+	created_svm, err := r.client.WithContext(ctx).CreateSVM(&svm)
 
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create SVM, got error: %s", err))
@@ -458,6 +467,7 @@ func (r *SVMResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Save data into Terraform state
 
 	data.UUID = types.String{Value: string(*created_svm.UUID)}
+	data.ID = data.UUID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -470,12 +480,13 @@ func (r *SVMResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	SVM, err := r.client.GetSVM(&data.UUID.Value, nil)
+	SVM, err := r.client.WithContext(ctx).GetSVM(&data.UUID.Value, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SVM, got error: %s", err))
 		return
 	}
 	data.UUID = types.String{Value: *SVM.UUID}
+	data.ID = data.UUID
 	data.Name = types.String{Value: SVM.Name}
 
 	// If applicable, this is a great opportunity to initialize any necessary
@@ -519,7 +530,7 @@ func (r *SVMResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// 	"__uuid": data.Id.Value,
 	// })
 
-	updated_SVM, err := r.client.UpdateSVM(&svm)
+	updated_SVM, err := r.client.WithContext(ctx).UpdateSVM(&svm)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update SVM, got error: %s", err))
 		return
@@ -529,6 +540,7 @@ func (r *SVMResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	tflog.Trace(ctx, "created a resource")
 
 	plan.UUID = types.String{Value: *updated_SVM.UUID}
+	plan.ID = plan.UUID
 	plan.Name = types.String{Value: updated_SVM.Name}
 
 	// Save updated data into Terraform state
@@ -548,7 +560,7 @@ func (r *SVMResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	svm := ontap.SVM{}
 	svm.UUID = &data.UUID.Value
 
-	r.client.DeleteSVM(&svm)
+	r.client.WithContext(ctx).DeleteSVM(&svm)
 
 	// If applicable, this is a great opportunity to initialize any necessary
 	// provider client data and make a call using it.
@@ -0,0 +1,66 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSVMResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSVMDestroyed("svm_acc", "svm_acc_renamed"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSVMResourceConfig("svm_acc"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_svm.test", "name", "svm_acc"),
+					resource.TestCheckResourceAttrSet("ontap_svm.test", "uuid"),
+					testAccCheckSVMExists("svm_acc"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccSVMResourceConfig("svm_acc_renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ontap_svm.test", "name", "svm_acc_renamed"),
+					testAccCheckSVMExists("svm_acc_renamed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccSVMResourceConfig(name string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "ontap_svm" "test" {
+  name    = %q
+  comment = "created by acceptance tests"
+}
+`, name)
+}
+
+func testAccCheckSVMExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccSimulator.SVM(name) == nil {
+			return fmt.Errorf("svm %s doesn't exist", name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSVMDestroyed(names ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, name := range names {
+			if testAccSimulator.SVM(name) != nil {
+				return fmt.Errorf("svm %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
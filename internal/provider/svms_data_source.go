@@ -0,0 +1,170 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &SVMsDataSource{}
+
+func NewSVMsDataSource() datasource.DataSource {
+	return &SVMsDataSource{}
+}
+
+// SVMsDataSource defines the data source implementation.
+type SVMsDataSource struct {
+	client *ontap.Client
+}
+
+// SVMsDataSourceModel describes the data source data model.
+type SVMsDataSourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	State    types.String `tfsdk:"state"`
+	Subtype  types.String `tfsdk:"subtype"`
+	IPSpace  types.String `tfsdk:"ipspace"`
+	Protocol types.String `tfsdk:"protocol"`
+
+	SVMs []SVMDataSourceModel `tfsdk:"svms"`
+}
+
+func (d *SVMsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_svms"
+}
+
+func (d *SVMsDataSource) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	// Every SVM attribute is returned by ONTAP
+	svm_attributes := svmDataSourceAttributes()
+	for name, attribute := range svm_attributes {
+		attribute.Required = false
+		attribute.Optional = false
+		attribute.Computed = true
+		svm_attributes[name] = attribute
+	}
+
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "SVMs of the cluster, i.e. to iterate over tenants with `for_each`",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Identifier of the data source, always `svms`",
+				Type:                types.StringType,
+				Computed:            true,
+			},
+			"name": {
+				MarkdownDescription: "Only return SVMs matching this name, `*` can be used as a wildcard, i.e. `tenant_*`",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"state": {
+				MarkdownDescription: "Only return SVMs in this state, i.e. `running` or `stopped`",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"subtype": {
+				MarkdownDescription: "Only return SVMs of this subtype, i.e. `default` or `dp_destination`",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"ipspace": {
+				MarkdownDescription: "Only return SVMs in this IPspace",
+				Type:                types.StringType,
+				Optional:            true,
+			},
+			"protocol": {
+				MarkdownDescription: "Only return SVMs with this protocol enabled, one of `cifs`, `fcp`, `iscsi`, `nfs`, `nvme` or `s3`",
+				Type:                types.StringType,
+				Optional:            true,
+				Validators: []tfsdk.AttributeValidator{
+					stringOneOf("cifs", "fcp", "iscsi", "nfs", "nvme", "s3"),
+				},
+			},
+			"svms": {
+				MarkdownDescription: "Matching SVMs, with the same attributes as the `ontap_svm` data source",
+				Computed:            true,
+				Attributes:          tfsdk.ListNestedAttributes(svm_attributes),
+			},
+		},
+	}, nil
+}
+
+func (d *SVMsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ontap.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ontap.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SVMsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SVMsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.Name.Null {
+		query.Set("name", ontap.QueryWildcard(data.Name.Value))
+	}
+	if !data.State.Null {
+		query.Set("state", data.State.Value)
+	}
+	if !data.Subtype.Null {
+		query.Set("subtype", data.Subtype.Value)
+	}
+	if !data.IPSpace.Null {
+		query.Set("ipspace.name", ontap.QueryLiteral(data.IPSpace.Value))
+	}
+	if !data.Protocol.Null {
+		query.Set(data.Protocol.Value+".enabled", "true")
+	}
+
+	svms, err := d.client.WithContext(ctx).GetSVMs(query)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SVMs, got error: %s", err))
+		return
+	}
+
+	data.ID = types.String{Value: "svms"}
+	data.SVMs = []SVMDataSourceModel{}
+	for i := range svms {
+		svm := SVMDataSourceModel{}
+		svm.fromSVM(&svms[i])
+
+		data.SVMs = append(data.SVMs, svm)
+	}
+
+	tflog.Trace(ctx, "read svms data source", map[string]interface{}{
+		"count": len(data.SVMs),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,90 @@
+package ontap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSVMsDataSource(t *testing.T) {
+	testAccSimulator.AddSVMWithFields("tenant_svms_a", map[string]interface{}{
+		"nfs": map[string]interface{}{"enabled": true},
+	})
+	testAccSimulator.AddSVMWithFields("tenant_svms_b", map[string]interface{}{
+		"state": "stopped",
+		"iscsi": map[string]interface{}{"enabled": true},
+	})
+	testAccSimulator.AddSVMWithFields("tenant_svms_dr", map[string]interface{}{
+		"subtype": "dp_destination",
+		"ipspace": map[string]interface{}{"name": "ips_dr"},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Name filtering, the SVMs have the attributes of ontap_svm
+			{
+				Config: testAccSVMsDataSourceConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.#", "3"),
+					resource.TestCheckResourceAttrSet("data.ontap_svms.test", "svms.0.uuid"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.name", "tenant_svms_a"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.state", "running"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.nfs", "true"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.1.name", "tenant_svms_b"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.2.name", "tenant_svms_dr"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.2.subtype", "dp_destination"),
+				),
+			},
+			// State filtering
+			{
+				Config: testAccSVMsDataSourceConfig(`state = "stopped"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.name", "tenant_svms_b"),
+				),
+			},
+			// Subtype filtering
+			{
+				Config: testAccSVMsDataSourceConfig(`subtype = "dp_destination"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.name", "tenant_svms_dr"),
+				),
+			},
+			// IPspace filtering
+			{
+				Config: testAccSVMsDataSourceConfig(`ipspace = "ips_dr"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.ipspace.name", "ips_dr"),
+				),
+			},
+			// Protocol filtering
+			{
+				Config: testAccSVMsDataSourceConfig(`protocol = "iscsi"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.#", "1"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.name", "tenant_svms_b"),
+					resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.0.iscsi", "true"),
+				),
+			},
+			// No match returns an empty list
+			{
+				Config: testAccSVMsDataSourceConfig(`protocol = "s3"`),
+				Check:  resource.TestCheckResourceAttr("data.ontap_svms.test", "svms.#", "0"),
+			},
+		},
+	})
+}
+
+func testAccSVMsDataSourceConfig(filters string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+data "ontap_svms" "test" {
+  name = "tenant_svms_*"
+  %s
+}
+`, filters)
+}
@@ -0,0 +1,244 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+/*
+****************************
+
+	string one of
+
+*****************************
+*/
+
+// stringOneOfValidator checks that a string attribute is one of the
+// allowed values
+type stringOneOfValidator struct {
+	values []string
+}
+
+func stringOneOf(values ...string) tfsdk.AttributeValidator {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(v.values, "`, `"))
+}
+
+func (v stringOneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &value)...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	for _, allowed := range v.values {
+		if value.Value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.AttributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.AttributePath, v.Description(ctx), value.Value),
+	)
+}
+
+/*
+****************************
+
+	string regexp
+
+*****************************
+*/
+
+// stringMatchValidator checks that a string attribute matches a regular
+// expression
+type stringMatchValidator struct {
+	regexp  *regexp.Regexp
+	message string
+}
+
+func stringMatches(re *regexp.Regexp, message string) tfsdk.AttributeValidator {
+	return stringMatchValidator{regexp: re, message: message}
+}
+
+func (v stringMatchValidator) Description(ctx context.Context) string {
+	return v.message
+}
+
+func (v stringMatchValidator) MarkdownDescription(ctx context.Context) string {
+	return v.message
+}
+
+func (v stringMatchValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &value)...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	if !v.regexp.MatchString(value.Value) {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.AttributePath, v.message, value.Value),
+		)
+	}
+}
+
+/*
+****************************
+
+	int64 list between
+
+*****************************
+*/
+
+// int64ListBetweenValidator checks that every element of a list of int64
+// is within min and max, inclusive
+type int64ListBetweenValidator struct {
+	min int64
+	max int64
+}
+
+func int64ListBetween(min, max int64) tfsdk.AttributeValidator {
+	return int64ListBetweenValidator{min: min, max: max}
+}
+
+func (v int64ListBetweenValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("values must be between %d and %d", v.min, v.max)
+}
+
+func (v int64ListBetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("values must be between `%d` and `%d`", v.min, v.max)
+}
+
+func (v int64ListBetweenValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var values []types.Int64
+
+	if req.AttributeConfig.IsNull() || req.AttributeConfig.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &values)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, value := range values {
+		if value.Null || value.Unknown {
+			continue
+		}
+		if value.Value < v.min || value.Value > v.max {
+			resp.Diagnostics.AddAttributeError(
+				req.AttributePath.AtListIndex(i),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute %s %s, got: %d", req.AttributePath.AtListIndex(i), v.Description(ctx), value.Value),
+			)
+		}
+	}
+}
+
+/*
+****************************
+
+	RFC3339 timestamp
+
+*****************************
+*/
+
+// rfc3339Validator checks that a string attribute is an RFC3339 timestamp
+type rfc3339Validator struct{}
+
+func stringIsRFC3339() tfsdk.AttributeValidator {
+	return rfc3339Validator{}
+}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp, i.e. 2022-10-01T12:00:00Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp, i.e. `2022-10-01T12:00:00Z`"
+}
+
+func (v rfc3339Validator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.String
+
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &value)...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, value.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.AttributePath, v.Description(ctx), value.Value),
+		)
+	}
+}
+
+/*
+****************************
+
+	int64 at least
+
+*****************************
+*/
+
+// int64AtLeastValidator checks that an int64 attribute is greater than or
+// equal to min
+type int64AtLeastValidator struct {
+	min int64
+}
+
+func int64AtLeast(min int64) tfsdk.AttributeValidator {
+	return int64AtLeastValidator{min: min}
+}
+
+func (v int64AtLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least `%d`", v.min)
+}
+
+func (v int64AtLeastValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var value types.Int64
+
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &value)...)
+
+	if resp.Diagnostics.HasError() || value.Null || value.Unknown {
+		return
+	}
+
+	if value.Value < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.AttributePath,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.AttributePath, v.Description(ctx), value.Value),
+		)
+	}
+}
@@ -0,0 +1,53 @@
+package ontap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// attributeVersion is the oldest ONTAP release supporting an attribute
+type attributeVersion struct {
+	Path       path.Path
+	Generation int64
+	Major      int64
+	Minor      int64
+}
+
+// checkAttributeVersions returns an error for every attribute set in the
+// configuration that the cluster doesn't support. Nothing is checked when the
+// provider couldn't detect the version of the cluster
+func checkAttributeVersions(ctx context.Context, client *ontap.Client, config tfsdk.Config, versions []attributeVersion) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client == nil || client.Version == nil {
+		return diags
+	}
+
+	for _, v := range versions {
+		if client.Version.AtLeast(v.Generation, v.Major, v.Minor) {
+			continue
+		}
+
+		var value attr.Value
+
+		diags.Append(config.GetAttribute(ctx, v.Path, &value)...)
+
+		if value == nil || value.IsNull() {
+			continue
+		}
+
+		diags.AddAttributeError(
+			v.Path,
+			"Unsupported Attribute",
+			fmt.Sprintf("%s requires ONTAP %d.%d.%d or later, the cluster is running ONTAP %s", v.Path, v.Generation, v.Major, v.Minor, client.Version),
+		)
+	}
+
+	return diags
+}
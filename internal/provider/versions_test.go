@@ -0,0 +1,75 @@
+package ontap
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestAccAttributeVersions(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.SetVersion(9, 7, 0)
+	svm_uuid := simulator.AddSVM("svm_versions")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_versions")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The version of the cluster is detected
+			{
+				Config: testAccSimulatorProviderConfig(simulator) + `
+data "ontap_cluster" "test" {
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ontap_cluster.test", "version", "9.7.0"),
+				),
+			},
+			// Attributes requiring a more recent release are rejected at
+			// plan time
+			{
+				Config:      testAccAttributeVersionsQtreeConfig(simulator, svm_uuid, volume_uuid, `qos_policy = "gold"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`qos_policy requires ONTAP 9.8.0 or later, the cluster is running\s+ONTAP 9.7.0`),
+			},
+			{
+				Config: testAccSimulatorProviderConfig(simulator) + `
+resource "ontap_lun" "test" {
+  svm        = "svm_versions"
+  name       = "/vol/vol_versions/lun1"
+  os_type    = "linux"
+  size       = 1048576
+  qos_policy = "gold"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`qos_policy requires ONTAP 9.10.1 or later, the cluster is running\s+ONTAP 9.7.0`),
+			},
+			// Other attributes are planned
+			{
+				Config:             testAccAttributeVersionsQtreeConfig(simulator, svm_uuid, volume_uuid, ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccAttributeVersionsQtreeConfig(simulator *ontaptest.Simulator, svm_uuid string, volume_uuid string, attributes string) string {
+	return testAccSimulatorProviderConfig(simulator) + fmt.Sprintf(`
+resource "ontap_qtree" "test" {
+  svm_uuid         = %q
+  volume_uuid      = %q
+  name             = "home"
+  security_style   = "unix"
+  unix_permissions = 755
+  %s
+}
+`, svm_uuid, volume_uuid, attributes)
+}
@@ -0,0 +1,47 @@
+package ontap
+
+import "net/url"
+
+type Aggregate struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name         string                 `json:"name,omitempty"`
+	Node         *UUIDRef               `json:"node,omitempty"`
+	State        string                 `json:"state,omitempty"`
+	Space        *AggregateSpace        `json:"space,omitempty"`
+	BlockStorage *AggregateBlockStorage `json:"block_storage,omitempty"`
+}
+
+type AggregateSpace struct {
+	BlockStorage AggregateSpaceBlockStorage `json:"block_storage"`
+}
+
+type AggregateSpaceBlockStorage struct {
+	Size      int64 `json:"size"`
+	Used      int64 `json:"used"`
+	Available int64 `json:"available"`
+}
+
+type AggregateBlockStorage struct {
+	StorageType string                        `json:"storage_type,omitempty"`
+	Primary     *AggregateBlockStoragePrimary `json:"primary,omitempty"`
+}
+
+type AggregateBlockStoragePrimary struct {
+	RAIDType  string `json:"raid_type,omitempty"`
+	DiskClass string `json:"disk_class,omitempty"`
+	DiskCount int64  `json:"disk_count,omitempty"`
+}
+
+// Fields requested when listing aggregates, as collection GETs only return
+// uuid and name by default
+const aggregateFields = "uuid,name,node,state,space.block_storage,block_storage.storage_type,block_storage.primary"
+
+// GetAggregates returns the aggregates matching an ONTAP query, i.e.
+// node.name=node1
+func (c *Client) GetAggregates(query url.Values) ([]Aggregate, error) {
+	return GetCollection[Aggregate](c, "/storage/aggregates", &CollectionQuery{
+		Query:  query,
+		Fields: []string{aggregateFields},
+	})
+}
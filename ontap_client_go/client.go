@@ -1,7 +1,7 @@
 package ontap
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -14,6 +14,26 @@ type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
 	Auth       AuthStruct
+
+	// Scheme is http or https, https when empty
+	Scheme string
+	// Port of the management interface, the default port of the scheme when 0
+	Port int
+	// BasePath is the path of the REST API, DefaultBasePath when empty, i.e.
+	// when the cluster is reached through a reverse proxy
+	BasePath string
+
+	// Version is the ONTAP version of the cluster, set by DetectVersion
+	Version *ClusterVersion
+
+	maxConnsPerHost int
+	sessionCookie   bool
+
+	semaphore   chan struct{}
+	rateLimiter *rateLimiter
+
+	// ctx is the context of the requests, set by WithContext
+	ctx context.Context
 }
 
 // AuthStruct -
@@ -22,19 +42,11 @@ type AuthStruct struct {
 	Password string `json:"password"`
 }
 
-type QtreeJSONRecordsResponse struct {
-	Records []QtreeJSONRecords `json:"records"`
-}
-
-type QtreeJSONRecords struct {
-	Name string `json:"name"`
-	ID   int    `json:"id"`
-}
-
 type JobResponseStruct struct {
 	Job JobResponseJob `json:"job"`
 }
 type JobResponseJob struct {
+	UUID  string            `json:"uuid"`
 	Links JSONResponseLinks `json:"_links"`
 }
 type JSONResponseLinks struct {
@@ -44,12 +56,18 @@ type JobResponseLinksSelf struct {
 	HREF string `json:"href"`
 }
 
+// CollectionLinks are the links of a collection response, Next is set when
+// more records are available
+type CollectionLinks struct {
+	Next *JobResponseLinksSelf `json:"next,omitempty"`
+}
+
 type JobStatus struct {
 	UUID        string `json:"uuid"`
 	Description string `json:"description"`
 	State       string `json:"state"`
 	Message     string `json:"message"`
-	Code        string `json:"code"`
+	Code        int64  `json:"code"`
 	Start_time  string `json:"start_time"`
 	End_time    string `json:"end_time"`
 }
@@ -71,22 +89,26 @@ func (e *Error404) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
-// NewClient -
-func NewClient(host, username, password *string, ignoreSSLErrors bool) (*Client, error) {
-	if ignoreSSLErrors {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-		_, err := http.Get("https://golang.org/")
-		if err != nil {
-			fmt.Println(err)
-		}
-	}
+// NewClient returns a client for the cluster at host, which is either a
+// hostname with an optional port or a URL, see ParseHost
+func NewClient(host, username, password *string, ignoreSSLErrors bool, options ...ClientOption) (*Client, error) {
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		// Default Hashicups URL
+		BasePath:        DefaultBasePath,
+		maxConnsPerHost: DefaultMaxConnsPerHost,
+	}
+
+	for _, option := range options {
+		option(&c)
 	}
 
+	c.HTTPClient = c.newHTTPClient(ignoreSSLErrors)
+
 	if host != nil {
-		c.HostURL = *host
+		err := c.setHost(*host)
+
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// If username or password not provided, return empty client
@@ -103,14 +125,7 @@ func NewClient(host, username, password *string, ignoreSSLErrors bool) (*Client,
 }
 
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	req.SetBasicAuth(c.Auth.Username, c.Auth.Password)
-	res, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	body, err := ioutil.ReadAll(res.Body)
+	res, body, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -122,32 +137,13 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 			return nil, err
 		}
 
-	jobLoop:
-		for {
-			req, err := http.NewRequest("GET", fmt.Sprintf("https://%s%s", c.HostURL, jobResponse.Job.Links.Self.HREF), nil)
-			if err != nil {
-				return nil, err
-			}
-			body, err = c.doRequest(req)
-			if err != nil {
-				return nil, err
-			}
-
-			jobStatus := JobStatus{}
-			err = json.Unmarshal(body, &jobStatus)
-
-			switch jobStatus.State {
-			case "error":
-			case "failure":
-				return nil, fmt.Errorf(jobStatus.Message)
-			case "success":
-				break jobLoop
-			}
-			time.Sleep(time.Second)
+		body, err = c.waitForJob(jobResponse.Job.Links.Self.HREF)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusAccepted {
 		if res.StatusCode == 404 {
 			errDescription := ErrorJSON{}
 
@@ -160,8 +156,123 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 				Message: errDescription.Error.Message,
 			}
 		}
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, RedactBody(body))
 	}
 
 	return body, err
 }
+
+// send sends req, authenticated with the session cookie when one is stored,
+// and returns the response with its body read so that the connection is
+// reused. When the session has expired, req is sent again with the
+// credentials.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	with_session := c.hasSession()
+	if !with_session {
+		req.SetBasicAuth(c.Auth.Username, c.Auth.Password)
+	}
+
+	res, body, err := c.roundTrip(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && with_session {
+		c.resetSession()
+
+		// The jar added the expired cookie to req, the retry must only be
+		// authenticated with the credentials
+		retry := req.Clone(req.Context())
+		retry.Header.Del("Cookie")
+		if req.GetBody != nil {
+			retry.Body, err = req.GetBody()
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return c.send(retry)
+	}
+
+	return res, body, nil
+}
+
+// roundTrip sends req once the concurrency and rate limits allow it, and
+// reads the response body
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	release, err := c.acquire()
+
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	req = req.WithContext(c.context())
+
+	c.traceRequest(req)
+	start := time.Now()
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.traceResponse(req, res, body, time.Since(start))
+
+	return res, body, nil
+}
+
+// waitForJob polls the job at href until it succeeds or fails, and returns
+// the last job status body
+func (c *Client) waitForJob(href string) ([]byte, error) {
+	for {
+		req, err := http.NewRequest("GET", c.linkURL(href), nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := c.doRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		jobStatus := JobStatus{}
+		err = json.Unmarshal(body, &jobStatus)
+		if err != nil {
+			return nil, err
+		}
+
+		c.traceJob(href, &jobStatus)
+
+		switch jobStatus.State {
+		case "error", "failure":
+			return nil, fmt.Errorf(jobStatus.Message)
+		case "success":
+			return body, nil
+		}
+
+		err = c.sleep(time.Second)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits d between two polls, it returns the context error as soon as
+// the context of the client is done, i.e. when Terraform is interrupted
+func (c *Client) sleep(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-c.context().Done():
+		return c.context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
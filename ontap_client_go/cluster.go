@@ -0,0 +1,166 @@
+package ontap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type Cluster struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name                 string             `json:"name,omitempty"`
+	Version              *ClusterVersion    `json:"version,omitempty"`
+	Location             string             `json:"location,omitempty"`
+	Contact              string             `json:"contact,omitempty"`
+	ManagementInterfaces []ClusterInterface `json:"management_interfaces,omitempty"`
+	DNSDomains           []string           `json:"dns_domains,omitempty"`
+	NameServers          []string           `json:"name_servers,omitempty"`
+	NTPServers           []string           `json:"ntp_servers,omitempty"`
+	Timezone             *ClusterTimezone   `json:"timezone,omitempty"`
+}
+
+// ClusterVersion is the ONTAP version of a cluster or node, i.e. 9.11.1 has
+// generation 9, major 11 and minor 1
+type ClusterVersion struct {
+	Full       string `json:"full,omitempty"`
+	Generation int64  `json:"generation,omitempty"`
+	Major      int64  `json:"major,omitempty"`
+	Minor      int64  `json:"minor,omitempty"`
+}
+
+// AtLeast returns whether the version is generation.major.minor or newer
+func (v *ClusterVersion) AtLeast(generation int64, major int64, minor int64) bool {
+	if v.Generation != generation {
+		return v.Generation > generation
+	}
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+func (v *ClusterVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Generation, v.Major, v.Minor)
+}
+
+type ClusterInterface struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name string                  `json:"name,omitempty"`
+	IP   *ClusterInterfaceIPInfo `json:"ip,omitempty"`
+}
+
+type ClusterInterfaceIPInfo struct {
+	Address string `json:"address,omitempty"`
+}
+
+type ClusterTimezone struct {
+	Name string `json:"name,omitempty"`
+}
+
+type ClusterNode struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name         string                 `json:"name,omitempty"`
+	Model        string                 `json:"model,omitempty"`
+	SerialNumber string                 `json:"serial_number,omitempty"`
+	SystemID     string                 `json:"system_id,omitempty"`
+	Location     string                 `json:"location,omitempty"`
+	Version      *ClusterVersion        `json:"version,omitempty"`
+	Uptime       int64                  `json:"uptime,omitempty"`
+	State        string                 `json:"state,omitempty"`
+	Membership   string                 `json:"membership,omitempty"`
+	HA           *ClusterNodeHA         `json:"ha,omitempty"`
+	Controller   *ClusterNodeController `json:"controller,omitempty"`
+}
+
+type ClusterNodeHA struct {
+	Enabled  bool                    `json:"enabled"`
+	Partners []UUIDRef               `json:"partners,omitempty"`
+	Takeover *ClusterNodeHAOperation `json:"takeover,omitempty"`
+	Giveback *ClusterNodeHAOperation `json:"giveback,omitempty"`
+}
+
+type ClusterNodeHAOperation struct {
+	State string `json:"state,omitempty"`
+}
+
+type ClusterNodeController struct {
+	OverTemperature string `json:"over_temperature,omitempty"`
+}
+
+// Fields requested for the cluster, NTP servers are not returned by default
+const clusterFields = "uuid,name,version,location,contact,management_interfaces,dns_domains,name_servers,ntp_servers,timezone"
+
+// Fields requested when listing nodes, as collection GETs only return uuid
+// and name by default
+const clusterNodeFields = "uuid,name,model,serial_number,system_id,location,version,uptime,state,membership,ha,controller.over_temperature"
+
+func (c *Client) GetCluster() (*Cluster, error) {
+
+	req, err := http.NewRequest("GET", c.restURL("/cluster", url.Values{"fields": {clusterFields}}), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := Cluster{}
+
+	err = json.Unmarshal(body, &cluster)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cluster, nil
+}
+
+// DetectVersion reads the ONTAP version of the cluster and caches it in
+// c.Version
+func (c *Client) DetectVersion() (*ClusterVersion, error) {
+
+	req, err := http.NewRequest("GET", c.restURL("/cluster", url.Values{"fields": {"version"}}), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := Cluster{}
+
+	err = json.Unmarshal(body, &cluster)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Version == nil {
+		return nil, fmt.Errorf("cluster version was not returned")
+	}
+
+	c.Version = cluster.Version
+
+	return c.Version, nil
+}
+
+// GetClusterNodes returns the nodes matching an ONTAP query, i.e.
+// name=cluster-01
+func (c *Client) GetClusterNodes(query url.Values) ([]ClusterNode, error) {
+	return GetCollection[ClusterNode](c, "/cluster/nodes", &CollectionQuery{
+		Query:  query,
+		Fields: []string{clusterNodeFields},
+	})
+}
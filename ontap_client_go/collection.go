@@ -0,0 +1,132 @@
+package ontap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CollectionQuery holds the parameters of a GET on an ONTAP collection
+type CollectionQuery struct {
+	// Query holds the ONTAP filters, i.e. name=svm1
+	Query url.Values
+	// Fields lists the fields returned for each record, collection GETs only
+	// return identifiers by default
+	Fields []string
+	// OrderBy sorts the records, i.e. "name desc"
+	OrderBy []string
+	// MaxRecords is the number of records returned per page, ONTAP picks the
+	// page size when it is 0
+	MaxRecords int64
+}
+
+// CollectionResponse is a page of records returned by a collection GET, or by
+// a POST with return_records=true
+type CollectionResponse[T any] struct {
+	NumRecords int64           `json:"num_records,omitempty"`
+	Records    []T             `json:"records,omitempty"`
+	Links      CollectionLinks `json:"_links,omitempty"`
+}
+
+func (q *CollectionQuery) values() url.Values {
+	values := url.Values{}
+
+	if q == nil {
+		return values
+	}
+
+	for k, v := range q.Query {
+		values[k] = v
+	}
+	if len(q.Fields) > 0 {
+		values.Set("fields", strings.Join(q.Fields, ","))
+	}
+	if len(q.OrderBy) > 0 {
+		values.Set("order_by", strings.Join(q.OrderBy, ","))
+	}
+	if q.MaxRecords > 0 {
+		values.Set("max_records", fmt.Sprintf("%d", q.MaxRecords))
+	}
+
+	return values
+}
+
+// GetCollection returns every record of the collection at path, relative to
+// the base path, matching the query, following _links.next until the last page is read
+func GetCollection[T any](c *Client, path string, query *CollectionQuery) ([]T, error) {
+
+	page_url := c.restURL(path, query.values())
+
+	records := []T{}
+
+	for page_url != "" {
+		req, err := http.NewRequest("GET", page_url, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := c.doRequest(req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page := CollectionResponse[T]{}
+		err = json.Unmarshal(body, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, page.Records...)
+
+		page_url = ""
+		if page.Links.Next != nil {
+			page_url = c.linkURL(page.Links.Next.HREF)
+		}
+	}
+
+	return records, nil
+}
+
+// GetCollectionRecord returns the first record of the collection at path
+// matching the query, or an Error404 when no record matches
+func GetCollectionRecord[T any](c *Client, path string, query *CollectionQuery) (*T, error) {
+
+	single_query := CollectionQuery{}
+	if query != nil {
+		single_query = *query
+	}
+	single_query.MaxRecords = 1
+
+	req, err := http.NewRequest("GET", c.restURL(path, single_query.values()), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	page := CollectionResponse[T]{}
+	err = json.Unmarshal(body, &page)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Records) == 0 {
+		return nil, &Error404{
+			Code:    "404",
+			Message: fmt.Sprintf("no record of %s matches %s", path, single_query.Query.Encode()),
+		}
+	}
+
+	return &page.Records[0], nil
+}
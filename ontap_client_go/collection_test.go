@@ -0,0 +1,171 @@
+package ontap_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestGetCollectionFollowsNextLink(t *testing.T) {
+	client := ontaptest.NewClient(t, "svms_pagination")
+
+	svms, err := client.GetSVMs(url.Values{"name": []string{"svm*"}})
+
+	if err != nil {
+		t.Fatalf("unable to get svms, got error: %s", err)
+	}
+
+	if len(svms) != 2 {
+		t.Fatalf("expected 2 svms, got %d", len(svms))
+	}
+
+	if svms[0].Name != "svm1" || svms[1].Name != "svm2" {
+		t.Errorf("expected svm1 and svm2, got %s and %s", svms[0].Name, svms[1].Name)
+	}
+}
+
+func TestGetCollectionRecordNotFound(t *testing.T) {
+	client := ontaptest.NewClient(t, "svm_not_found")
+
+	name := "missing"
+	_, err := client.GetSVM(nil, &name)
+
+	var not_found *ontap.Error404
+	if !errors.As(err, &not_found) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestGetCollectionReadsEveryPage(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.PageSize = 2
+	for _, name := range []string{"page_c", "page_a", "page_e", "page_b", "page_d"} {
+		simulator.AddSVM(name)
+	}
+
+	client := simulator.Client(t)
+
+	svms, err := ontap.GetCollection[ontap.SVM](client, "/svm/svms", &ontap.CollectionQuery{
+		Query:   url.Values{"name": []string{"page_*"}},
+		Fields:  []string{"name", "state"},
+		OrderBy: []string{"name desc"},
+	})
+
+	if err != nil {
+		t.Fatalf("unable to get svms, got error: %s", err)
+	}
+
+	names := []string{}
+	for _, svm := range svms {
+		names = append(names, svm.Name)
+		if svm.State != "running" {
+			t.Errorf("expected state of svm %s to be returned, got %q", svm.Name, svm.State)
+		}
+	}
+
+	if strings.Join(names, ",") != "page_e,page_d,page_c,page_b,page_a" {
+		t.Errorf("expected svms page_e to page_a, got %v", names)
+	}
+}
+
+func TestGetCollectionMaxRecords(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	for _, name := range []string{"max_a", "max_b", "max_c"} {
+		simulator.AddSVM(name)
+	}
+
+	client := simulator.Client(t)
+
+	// Pages of max_records records are followed to the last one
+	svms, err := ontap.GetCollection[ontap.SVM](client, "/svm/svms", &ontap.CollectionQuery{
+		Query:      url.Values{"name": []string{"max_*"}},
+		MaxRecords: 1,
+	})
+
+	if err != nil {
+		t.Fatalf("unable to get svms, got error: %s", err)
+	}
+
+	if len(svms) != 3 {
+		t.Errorf("expected 3 svms, got %d", len(svms))
+	}
+
+	// A single record is requested for a lookup
+	svm, err := ontap.GetCollectionRecord[ontap.SVM](client, "/svm/svms", &ontap.CollectionQuery{
+		Query:   url.Values{"name": []string{"max_*"}},
+		OrderBy: []string{"name desc"},
+	})
+
+	if err != nil {
+		t.Fatalf("unable to get svm, got error: %s", err)
+	}
+
+	if svm.Name != "max_c" {
+		t.Errorf("expected svm max_c, got %s", svm.Name)
+	}
+}
+
+func TestGetQtreeInVolumeNotFound(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	svm_uuid := simulator.AddSVM("svm_empty")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_empty")
+
+	client := simulator.Client(t)
+
+	_, err := client.GetQtreeInVolume(volume_uuid, "missing")
+
+	var not_found *ontap.Error404
+	if !errors.As(err, &not_found) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestGetSVMByNameSingleCall(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	uuid := simulator.AddSVMWithFields("svm_lookup", map[string]interface{}{"comment": "lookup"})
+
+	client := simulator.Client(t)
+
+	name := "svm_lookup"
+	svm, err := client.GetSVM(nil, &name)
+
+	if err != nil {
+		t.Fatalf("unable to get svm, got error: %s", err)
+	}
+
+	if svm.UUID == nil || *svm.UUID != uuid || svm.Comment != "lookup" || svm.State != "running" {
+		t.Errorf("expected svm %s with all its fields, got %+v", uuid, svm)
+	}
+
+	svm_uuid, err := client.GetSVMUUID(name)
+
+	if err != nil {
+		t.Fatalf("unable to get svm uuid, got error: %s", err)
+	}
+
+	if svm_uuid != uuid {
+		t.Errorf("expected uuid %s, got %s", uuid, svm_uuid)
+	}
+
+	requests := simulator.Requests()
+
+	if len(requests) != 2 {
+		t.Fatalf("expected one request per lookup, got %v", requests)
+	}
+
+	if !strings.Contains(requests[1], "fields=uuid") || strings.Contains(requests[1], "%2A") {
+		t.Errorf("expected the uuid lookup to only request the uuid, got %s", requests[1])
+	}
+}
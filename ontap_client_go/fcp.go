@@ -0,0 +1,137 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type FCPService struct {
+	SVM     *UUIDRef          `json:"svm,omitempty"`
+	Enabled *bool             `json:"enabled,omitempty"`
+	Target  *FCPServiceTarget `json:"target,omitempty"`
+}
+
+// FCPServiceTarget is the FC target of the SVM, its name is the world wide
+// node name assigned by ONTAP
+type FCPServiceTarget struct {
+	Name string `json:"name,omitempty"`
+}
+
+func (c *Client) CreateFCPService(service *FCPService) (*FCPService, error) {
+
+	req_body, err := json.Marshal(service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/san/fcp/services", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service_result := CollectionResponse[FCPService]{}
+	err = json.Unmarshal(body, &service_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(service_result.Records) == 0 || service_result.Records[0].SVM == nil {
+		return nil, fmt.Errorf("fcp service was not returned after creation")
+	}
+
+	return c.GetFCPService(service_result.Records[0].SVM.UUID)
+}
+
+func (c *Client) GetFCPService(svm_uuid string) (*FCPService, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/san/fcp/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service := FCPService{}
+
+	err = json.Unmarshal(body, &service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+func (c *Client) UpdateFCPService(service *FCPService) (*FCPService, error) {
+
+	service_copy := *service
+	service_copy.SVM = nil
+	service_copy.Target = nil
+
+	req_body, err := json.Marshal(service_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/san/fcp/services/%s", service.SVM.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetFCPService(service.SVM.UUID)
+}
+
+// DeleteFCPService disables the FC service, as ONTAP refuses to delete a
+// running service, then deletes it
+func (c *Client) DeleteFCPService(svm_uuid string) error {
+
+	disabled := false
+
+	_, err := c.UpdateFCPService(&FCPService{
+		SVM:     &UUIDRef{UUID: svm_uuid},
+		Enabled: &disabled,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/fcp/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
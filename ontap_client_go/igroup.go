@@ -0,0 +1,247 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type IGroup struct {
+	UUID string `json:"uuid,omitempty"`
+
+	SVM        *UUIDRef          `json:"svm,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Protocol   string            `json:"protocol,omitempty"`
+	OSType     string            `json:"os_type,omitempty"`
+	Comment    *string           `json:"comment,omitempty"`
+	Initiators []IGroupInitiator `json:"initiators,omitempty"`
+	IGroups    []UUIDRef         `json:"igroups,omitempty"`
+	Portset    *UUIDRef          `json:"portset,omitempty"`
+}
+
+type IGroupInitiator struct {
+	Name string `json:"name,omitempty"`
+}
+
+func (c *Client) CreateIGroup(igroup *IGroup) (*IGroup, error) {
+
+	igroup_copy := *igroup
+	igroup_copy.UUID = ""
+
+	req_body, err := json.Marshal(igroup_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/san/igroups", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	igroup_result := CollectionResponse[IGroup]{}
+	err = json.Unmarshal(body, &igroup_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(igroup_result.Records) == 0 {
+		return nil, fmt.Errorf("igroup %s was not returned after creation", igroup.Name)
+	}
+
+	created_igroup, err := c.GetIGroup(igroup_result.Records[0].UUID)
+
+	if err != nil {
+		delete_err := c.DeleteIGroup(&igroup_result.Records[0])
+
+		if delete_err != nil {
+			return nil, fmt.Errorf("%w (cleanup failed: %v)", err, delete_err)
+		}
+		return nil, err
+	}
+
+	return created_igroup, nil
+}
+
+func (c *Client) GetIGroup(uuid string) (*IGroup, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s", uuid), url.Values{"fields": {"*,initiators.name,igroups.name,portset.name"}}), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	igroup := IGroup{}
+
+	err = json.Unmarshal(body, &igroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &igroup, nil
+}
+
+// igroupPatch is the body of an igroup PATCH, an empty portset name unbinds
+// the portset from the igroup
+type igroupPatch struct {
+	Name    string         `json:"name,omitempty"`
+	OSType  string         `json:"os_type,omitempty"`
+	Comment *string        `json:"comment,omitempty"`
+	Portset *igroupPortset `json:"portset,omitempty"`
+}
+
+type igroupPortset struct {
+	Name string `json:"name"`
+}
+
+// UpdateIGroup modifies the igroup properties, initiators and nested igroups
+// are changed with AddIGroupInitiator, RemoveIGroupInitiator, AddIGroupChild
+// and RemoveIGroupChild
+func (c *Client) UpdateIGroup(igroup *IGroup) (*IGroup, error) {
+
+	igroup_patch := igroupPatch{
+		Name:    igroup.Name,
+		OSType:  igroup.OSType,
+		Comment: igroup.Comment,
+	}
+	if igroup.Portset != nil {
+		igroup_patch.Portset = &igroupPortset{Name: igroup.Portset.Name}
+	}
+
+	req_body, err := json.Marshal(igroup_patch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s", igroup.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetIGroup(igroup.UUID)
+}
+
+func (c *Client) DeleteIGroup(igroup *IGroup) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s", igroup.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) AddIGroupInitiator(igroup_uuid string, name string) error {
+
+	req_body, err := json.Marshal(IGroupInitiator{Name: name})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s/initiators", igroup_uuid), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) RemoveIGroupInitiator(igroup_uuid string, name string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s/initiators/%s", igroup_uuid, url.PathEscape(name)), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddIGroupChild nests the igroup named name into the igroup igroup_uuid
+func (c *Client) AddIGroupChild(igroup_uuid string, name string) error {
+
+	req_body, err := json.Marshal(UUIDRef{Name: name})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s/igroups", igroup_uuid), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveIGroupChild removes the nested igroup child_uuid from the igroup
+// igroup_uuid
+func (c *Client) RemoveIGroupChild(igroup_uuid string, child_uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/igroups/%s/igroups/%s", igroup_uuid, child_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type ISCSIService struct {
+	SVM     *UUIDRef            `json:"svm,omitempty"`
+	Enabled *bool               `json:"enabled,omitempty"`
+	Target  *ISCSIServiceTarget `json:"target,omitempty"`
+}
+
+type ISCSIServiceTarget struct {
+	Name  string `json:"name,omitempty"`
+	Alias string `json:"alias,omitempty"`
+}
+
+type ISCSICredentials struct {
+	SVM                *UUIDRef               `json:"svm,omitempty"`
+	Initiator          string                 `json:"initiator,omitempty"`
+	AuthenticationType string                 `json:"authentication_type,omitempty"`
+	CHAP               *ISCSICredentialsCHAP  `json:"chap,omitempty"`
+	InitiatorAddress   *ISCSIInitiatorAddress `json:"initiator_address,omitempty"`
+}
+
+type ISCSICredentialsCHAP struct {
+	Inbound  *ISCSICHAPUser `json:"inbound,omitempty"`
+	Outbound *ISCSICHAPUser `json:"outbound,omitempty"`
+}
+
+// ISCSICHAPUser is a CHAP user, ONTAP never returns the password
+type ISCSICHAPUser struct {
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type ISCSIInitiatorAddress struct {
+	Ranges []ISCSIInitiatorAddressRange `json:"ranges"`
+}
+
+type ISCSIInitiatorAddressRange struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+func (c *Client) CreateISCSIService(service *ISCSIService) (*ISCSIService, error) {
+
+	req_body, err := json.Marshal(service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/san/iscsi/services", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service_result := CollectionResponse[ISCSIService]{}
+	err = json.Unmarshal(body, &service_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(service_result.Records) == 0 || service_result.Records[0].SVM == nil {
+		return nil, fmt.Errorf("iscsi service was not returned after creation")
+	}
+
+	return c.GetISCSIService(service_result.Records[0].SVM.UUID)
+}
+
+func (c *Client) GetISCSIService(svm_uuid string) (*ISCSIService, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/san/iscsi/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service := ISCSIService{}
+
+	err = json.Unmarshal(body, &service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+func (c *Client) UpdateISCSIService(service *ISCSIService) (*ISCSIService, error) {
+
+	service_copy := *service
+	service_copy.SVM = nil
+
+	req_body, err := json.Marshal(service_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/san/iscsi/services/%s", service.SVM.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetISCSIService(service.SVM.UUID)
+}
+
+// DeleteISCSIService disables the iSCSI service, as ONTAP refuses to delete
+// a running service, then deletes it
+func (c *Client) DeleteISCSIService(svm_uuid string) error {
+
+	disabled := false
+
+	_, err := c.UpdateISCSIService(&ISCSIService{
+		SVM:     &UUIDRef{UUID: svm_uuid},
+		Enabled: &disabled,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/iscsi/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ISCSIDefaultInitiator is the initiator of the credentials applied to
+// initiators without credentials. ONTAP creates them with the iSCSI service
+// and refuses to delete them.
+const ISCSIDefaultInitiator = "default"
+
+// CreateISCSICredentials creates the credentials of an initiator, the
+// credentials of ISCSIDefaultInitiator are updated instead
+func (c *Client) CreateISCSICredentials(credentials *ISCSICredentials) (*ISCSICredentials, error) {
+
+	if credentials.Initiator == ISCSIDefaultInitiator {
+		return c.updateISCSIDefaultCredentials(credentials)
+	}
+
+	req_body, err := json.Marshal(credentials)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/san/iscsi/credentials", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	credentials_result := CollectionResponse[ISCSICredentials]{}
+	err = json.Unmarshal(body, &credentials_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(credentials_result.Records) == 0 || credentials_result.Records[0].SVM == nil {
+		return nil, fmt.Errorf("iscsi credentials for %s were not returned after creation", credentials.Initiator)
+	}
+
+	return c.GetISCSICredentials(credentials_result.Records[0].SVM.UUID, credentials.Initiator)
+}
+
+func (c *Client) GetISCSICredentials(svm_uuid string, initiator string) (*ISCSICredentials, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/san/iscsi/credentials/%s/%s", svm_uuid, url.PathEscape(initiator)), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := ISCSICredentials{}
+
+	err = json.Unmarshal(body, &credentials)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &credentials, nil
+}
+
+func (c *Client) UpdateISCSICredentials(credentials *ISCSICredentials) (*ISCSICredentials, error) {
+
+	credentials_copy := *credentials
+	credentials_copy.SVM = nil
+	credentials_copy.Initiator = ""
+
+	req_body, err := json.Marshal(credentials_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/san/iscsi/credentials/%s/%s", credentials.SVM.UUID, url.PathEscape(credentials.Initiator)), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetISCSICredentials(credentials.SVM.UUID, credentials.Initiator)
+}
+
+// updateISCSIDefaultCredentials updates the default credentials of the SVM
+// of credentials, designated by name
+func (c *Client) updateISCSIDefaultCredentials(credentials *ISCSICredentials) (*ISCSICredentials, error) {
+
+	current, err := GetCollectionRecord[ISCSICredentials](c, "/protocols/san/iscsi/credentials", &CollectionQuery{
+		Query: url.Values{"svm.name": []string{QueryLiteral(credentials.SVM.Name)}, "initiator": []string{ISCSIDefaultInitiator}},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if current.SVM == nil {
+		return nil, fmt.Errorf("default iscsi credentials of svm %s were returned without their svm", credentials.SVM.Name)
+	}
+
+	credentials_copy := *credentials
+	credentials_copy.SVM = &UUIDRef{UUID: current.SVM.UUID}
+
+	return c.UpdateISCSICredentials(&credentials_copy)
+}
+
+// DeleteISCSICredentials deletes the credentials of an initiator, the
+// credentials of ISCSIDefaultInitiator are reset to no authentication
+func (c *Client) DeleteISCSICredentials(svm_uuid string, initiator string) error {
+
+	if initiator == ISCSIDefaultInitiator {
+		_, err := c.UpdateISCSICredentials(&ISCSICredentials{
+			SVM:                &UUIDRef{UUID: svm_uuid},
+			Initiator:          initiator,
+			AuthenticationType: "none",
+		})
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/iscsi/credentials/%s/%s", svm_uuid, url.PathEscape(initiator)), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
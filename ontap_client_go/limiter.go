@@ -0,0 +1,110 @@
+package ontap
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithMaxConcurrentRequests limits the number of requests sent to the cluster
+// at the same time, other requests wait for one to complete
+func WithMaxConcurrentRequests(max int) ClientOption {
+	return func(c *Client) {
+		if max > 0 {
+			c.semaphore = make(chan struct{}, max)
+		}
+	}
+}
+
+// WithRequestsPerSecond limits the rate of requests sent to the cluster, with
+// bursts of up to one second worth of requests
+func WithRequestsPerSecond(rate int) ClientOption {
+	return func(c *Client) {
+		if rate > 0 {
+			c.rateLimiter = newRateLimiter(rate)
+		}
+	}
+}
+
+// acquire waits until a request can be sent, and returns the function
+// releasing its slot once the response is read. It returns the context error
+// if the context of the client is done while waiting.
+func (c *Client) acquire() (func(), error) {
+	ctx := c.context()
+
+	if c.rateLimiter != nil {
+		err := c.rateLimiter.wait(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.semaphore == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case c.semaphore <- struct{}{}:
+	}
+
+	return func() { <-c.semaphore }, nil
+}
+
+// rateLimiter is a token bucket refilled with rate tokens per second, a
+// request takes a token or waits for the next one
+type rateLimiter struct {
+	mutex sync.Mutex
+
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate int) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token, sleeping until it is available. Tokens are reserved
+// when taken so concurrent callers wait in turn, a caller giving up when ctx
+// is done returns its token.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mutex.Lock()
+
+	now := time.Now()
+
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+
+	l.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
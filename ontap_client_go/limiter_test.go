@@ -0,0 +1,165 @@
+package ontap_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+// getSVMsConcurrently gets the SVMs with the given number of requests sent at
+// the same time, and fails the test if one of them fails
+func getSVMsConcurrently(t *testing.T, client *ontap.Client, requests int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := client.GetSVMs(nil)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unable to get svms, got error: %s", err)
+		}
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.Latency = 20 * time.Millisecond
+
+	client := simulator.Client(t, ontap.WithMaxConcurrentRequests(3))
+
+	getSVMsConcurrently(t, client, 12)
+
+	if peak := simulator.PeakConcurrency(); peak != 3 {
+		t.Errorf("expected 3 requests at the same time, got %d", peak)
+	}
+}
+
+func TestUnlimitedConcurrentRequests(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.Latency = 20 * time.Millisecond
+
+	client := simulator.Client(t)
+
+	getSVMsConcurrently(t, client, 12)
+
+	if peak := simulator.PeakConcurrency(); peak <= 3 {
+		t.Errorf("expected more than 3 requests at the same time, got %d", peak)
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	client := simulator.Client(t, ontap.WithRequestsPerSecond(20))
+
+	// The first 20 requests are a burst, the 10 following ones take half a
+	// second
+	start := time.Now()
+
+	for i := 0; i < 30; i++ {
+		_, err := client.GetSVMs(nil)
+
+		if err != nil {
+			t.Fatalf("unable to get svms, got error: %s", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected 30 requests to take about 500ms, took %s", elapsed)
+	}
+}
+
+func TestMaxConcurrentRequestsCancellation(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.Latency = 500 * time.Millisecond
+
+	client := simulator.Client(t, ontap.WithMaxConcurrentRequests(1))
+
+	// A request holds the only slot while the other one is cancelled
+	done := make(chan error)
+	go func() {
+		_, err := client.GetSVMs(nil)
+		done <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.WithContext(ctx).GetSVMs(nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the waiting request to be cancelled, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("expected the waiting request to return when cancelled, took %s", elapsed)
+	}
+
+	if err := <-done; err != nil {
+		t.Errorf("unable to get svms, got error: %s", err)
+	}
+
+	if requests := len(simulator.Requests()); requests != 1 {
+		t.Errorf("expected the cancelled request not to be sent, got %d requests", requests)
+	}
+}
+
+func TestRequestsPerSecondCancellation(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	client := simulator.Client(t, ontap.WithRequestsPerSecond(1))
+
+	_, err := client.GetSVMs(nil)
+
+	if err != nil {
+		t.Fatalf("unable to get svms, got error: %s", err)
+	}
+
+	// The next token is available in a second
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.WithContext(ctx).GetSVMs(nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the waiting request to be cancelled, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the waiting request to return when cancelled, took %s", elapsed)
+	}
+
+	if requests := len(simulator.Requests()); requests != 1 {
+		t.Errorf("expected the cancelled request not to be sent, got %d requests", requests)
+	}
+}
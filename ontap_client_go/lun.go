@@ -0,0 +1,167 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type LUN struct {
+	UUID string `json:"uuid,omitempty"`
+
+	SVM          *UUIDRef     `json:"svm,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	OSType       string       `json:"os_type,omitempty"`
+	Space        *LUNSpace    `json:"space,omitempty"`
+	QOSPolicy    *UUIDRef     `json:"qos_policy,omitempty"`
+	Comment      *string      `json:"comment,omitempty"`
+	Enabled      *bool        `json:"enabled,omitempty"`
+	SerialNumber string       `json:"serial_number,omitempty"`
+	Location     *LUNLocation `json:"location,omitempty"`
+	Status       *LUNStatus   `json:"status,omitempty"`
+}
+
+type LUNSpace struct {
+	Size      int64              `json:"size,omitempty"`
+	Used      int64              `json:"used,omitempty"`
+	Guarantee *LUNSpaceGuarantee `json:"guarantee,omitempty"`
+}
+
+type LUNSpaceGuarantee struct {
+	Requested *bool `json:"requested,omitempty"`
+	Reserved  *bool `json:"reserved,omitempty"`
+}
+
+type LUNLocation struct {
+	LogicalUnit string   `json:"logical_unit,omitempty"`
+	Volume      *UUIDRef `json:"volume,omitempty"`
+	Qtree       *UUIDRef `json:"qtree,omitempty"`
+}
+
+type LUNStatus struct {
+	State  string `json:"state,omitempty"`
+	Mapped bool   `json:"mapped,omitempty"`
+}
+
+func (c *Client) CreateLUN(lun *LUN) (*LUN, error) {
+
+	lun_copy := *lun
+	lun_copy.UUID = ""
+
+	req_body, err := json.Marshal(lun_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/storage/luns", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lun_result := CollectionResponse[LUN]{}
+	err = json.Unmarshal(body, &lun_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lun_result.Records) == 0 {
+		return nil, fmt.Errorf("lun %s was not returned after creation", lun.Name)
+	}
+
+	return c.GetLUN(lun_result.Records[0].UUID)
+}
+
+func (c *Client) GetLUN(uuid string) (*LUN, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/storage/luns/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lun := LUN{}
+
+	err = json.Unmarshal(body, &lun)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &lun, nil
+}
+
+// GetLUNByPath looks up a LUN by SVM name and path, i.e. /vol/vol1/lun1
+func (c *Client) GetLUNByPath(svm_name string, path string) (*LUN, error) {
+
+	return GetCollectionRecord[LUN](c, "/storage/luns", &CollectionQuery{
+		Query:  url.Values{"svm.name": []string{QueryLiteral(svm_name)}, "name": []string{QueryLiteral(path)}},
+		Fields: []string{"*"},
+	})
+}
+
+// UpdateLUN modifies a LUN, a different size resizes it in place and a
+// different name moves it
+func (c *Client) UpdateLUN(lun *LUN) (*LUN, error) {
+
+	lun_copy := *lun
+	lun_copy.UUID = ""
+	lun_copy.SVM = nil
+	lun_copy.OSType = ""
+	lun_copy.SerialNumber = ""
+	lun_copy.Location = nil
+	lun_copy.Status = nil
+
+	req_body, err := json.Marshal(lun_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/storage/luns/%s", lun.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetLUN(lun.UUID)
+}
+
+func (c *Client) DeleteLUN(lun *LUN) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/storage/luns/%s", lun.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
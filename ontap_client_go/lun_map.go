@@ -0,0 +1,135 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type LUNMap struct {
+	SVM               *UUIDRef  `json:"svm,omitempty"`
+	LUN               *UUIDRef  `json:"lun,omitempty"`
+	IGroup            *UUIDRef  `json:"igroup,omitempty"`
+	LogicalUnitNumber *int64    `json:"logical_unit_number,omitempty"`
+	ReportingNodes    []UUIDRef `json:"reporting_nodes,omitempty"`
+}
+
+func (c *Client) CreateLUNMap(lun_map *LUNMap) (*LUNMap, error) {
+
+	req_body, err := json.Marshal(lun_map)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/san/lun-maps", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lun_map_result := CollectionResponse[LUNMap]{}
+	err = json.Unmarshal(body, &lun_map_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lun_map_result.Records) == 0 || lun_map_result.Records[0].LUN == nil || lun_map_result.Records[0].IGroup == nil {
+		return nil, fmt.Errorf("lun map %s to %s was not returned after creation", lun_map.LUN.Name, lun_map.IGroup.Name)
+	}
+
+	return c.GetLUNMap(lun_map_result.Records[0].LUN.UUID, lun_map_result.Records[0].IGroup.UUID)
+}
+
+func (c *Client) GetLUNMap(lun_uuid string, igroup_uuid string) (*LUNMap, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/san/lun-maps/%s/%s", lun_uuid, igroup_uuid), url.Values{"fields": {"*,reporting_nodes"}}), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lun_map := LUNMap{}
+
+	err = json.Unmarshal(body, &lun_map)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &lun_map, nil
+}
+
+func (c *Client) DeleteLUNMap(lun_uuid string, igroup_uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/lun-maps/%s/%s", lun_uuid, igroup_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddLUNMapReportingNode adds the node named name, along with its HA
+// partner, to the nodes reporting the LUN map
+func (c *Client) AddLUNMapReportingNode(lun_uuid string, igroup_uuid string, name string) error {
+
+	req_body, err := json.Marshal(UUIDRef{Name: name})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL(fmt.Sprintf("/protocols/san/lun-maps/%s/%s/reporting-nodes", lun_uuid, igroup_uuid), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) RemoveLUNMapReportingNode(lun_uuid string, igroup_uuid string, node_uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/san/lun-maps/%s/%s/reporting-nodes/%s", lun_uuid, igroup_uuid, node_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
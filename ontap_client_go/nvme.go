@@ -0,0 +1,492 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type NVMEService struct {
+	SVM     *UUIDRef `json:"svm,omitempty"`
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+type NVMENamespace struct {
+	UUID string `json:"uuid,omitempty"`
+
+	SVM     *UUIDRef             `json:"svm,omitempty"`
+	Name    string               `json:"name,omitempty"`
+	OSType  string               `json:"os_type,omitempty"`
+	Space   *NVMENamespaceSpace  `json:"space,omitempty"`
+	Comment *string              `json:"comment,omitempty"`
+	Status  *NVMENamespaceStatus `json:"status,omitempty"`
+}
+
+type NVMENamespaceSpace struct {
+	Size      int64 `json:"size,omitempty"`
+	BlockSize int64 `json:"block_size,omitempty"`
+	Used      int64 `json:"used,omitempty"`
+}
+
+type NVMENamespaceStatus struct {
+	State  string `json:"state,omitempty"`
+	Mapped bool   `json:"mapped,omitempty"`
+}
+
+type NVMESubsystem struct {
+	UUID string `json:"uuid,omitempty"`
+
+	SVM          *UUIDRef            `json:"svm,omitempty"`
+	Name         string              `json:"name,omitempty"`
+	OSType       string              `json:"os_type,omitempty"`
+	Comment      *string             `json:"comment,omitempty"`
+	Hosts        []NVMESubsystemHost `json:"hosts,omitempty"`
+	TargetNQN    string              `json:"target_nqn,omitempty"`
+	SerialNumber string              `json:"serial_number,omitempty"`
+}
+
+type NVMESubsystemHost struct {
+	NQN string `json:"nqn,omitempty"`
+}
+
+type NVMESubsystemMap struct {
+	SVM       *UUIDRef `json:"svm,omitempty"`
+	Subsystem *UUIDRef `json:"subsystem,omitempty"`
+	Namespace *UUIDRef `json:"namespace,omitempty"`
+}
+
+func (c *Client) CreateNVMEService(service *NVMEService) (*NVMEService, error) {
+
+	req_body, err := json.Marshal(service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/nvme/services", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service_result := CollectionResponse[NVMEService]{}
+	err = json.Unmarshal(body, &service_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(service_result.Records) == 0 || service_result.Records[0].SVM == nil {
+		return nil, fmt.Errorf("nvme service was not returned after creation")
+	}
+
+	return c.GetNVMEService(service_result.Records[0].SVM.UUID)
+}
+
+func (c *Client) GetNVMEService(svm_uuid string) (*NVMEService, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/nvme/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service := NVMEService{}
+
+	err = json.Unmarshal(body, &service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+func (c *Client) UpdateNVMEService(service *NVMEService) (*NVMEService, error) {
+
+	service_copy := *service
+	service_copy.SVM = nil
+
+	req_body, err := json.Marshal(service_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/nvme/services/%s", service.SVM.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetNVMEService(service.SVM.UUID)
+}
+
+// DeleteNVMEService disables the NVMe service, as ONTAP refuses to delete a
+// running service, then deletes it
+func (c *Client) DeleteNVMEService(svm_uuid string) error {
+
+	disabled := false
+
+	_, err := c.UpdateNVMEService(&NVMEService{
+		SVM:     &UUIDRef{UUID: svm_uuid},
+		Enabled: &disabled,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/nvme/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) CreateNVMENamespace(namespace *NVMENamespace) (*NVMENamespace, error) {
+
+	namespace_copy := *namespace
+	namespace_copy.UUID = ""
+
+	req_body, err := json.Marshal(namespace_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/storage/namespaces", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	namespace_result := CollectionResponse[NVMENamespace]{}
+	err = json.Unmarshal(body, &namespace_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(namespace_result.Records) == 0 {
+		return nil, fmt.Errorf("namespace %s was not returned after creation", namespace.Name)
+	}
+
+	return c.GetNVMENamespace(namespace_result.Records[0].UUID)
+}
+
+func (c *Client) GetNVMENamespace(uuid string) (*NVMENamespace, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/storage/namespaces/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := NVMENamespace{}
+
+	err = json.Unmarshal(body, &namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespace, nil
+}
+
+// UpdateNVMENamespace modifies a namespace, a different size resizes it in
+// place and a different name moves it
+func (c *Client) UpdateNVMENamespace(namespace *NVMENamespace) (*NVMENamespace, error) {
+
+	namespace_copy := *namespace
+	namespace_copy.UUID = ""
+	namespace_copy.SVM = nil
+	namespace_copy.OSType = ""
+	namespace_copy.Status = nil
+	if namespace_copy.Space != nil {
+		namespace_copy.Space = &NVMENamespaceSpace{Size: namespace.Space.Size}
+	}
+
+	req_body, err := json.Marshal(namespace_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/storage/namespaces/%s", namespace.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetNVMENamespace(namespace.UUID)
+}
+
+func (c *Client) DeleteNVMENamespace(namespace *NVMENamespace) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/storage/namespaces/%s", namespace.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) CreateNVMESubsystem(subsystem *NVMESubsystem) (*NVMESubsystem, error) {
+
+	subsystem_copy := *subsystem
+	subsystem_copy.UUID = ""
+
+	req_body, err := json.Marshal(subsystem_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/nvme/subsystems", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subsystem_result := CollectionResponse[NVMESubsystem]{}
+	err = json.Unmarshal(body, &subsystem_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subsystem_result.Records) == 0 {
+		return nil, fmt.Errorf("subsystem %s was not returned after creation", subsystem.Name)
+	}
+
+	created_subsystem, err := c.GetNVMESubsystem(subsystem_result.Records[0].UUID)
+
+	if err != nil {
+		delete_err := c.DeleteNVMESubsystem(&subsystem_result.Records[0])
+
+		if delete_err != nil {
+			return nil, fmt.Errorf("%w (cleanup failed: %v)", err, delete_err)
+		}
+		return nil, err
+	}
+
+	return created_subsystem, nil
+}
+
+func (c *Client) GetNVMESubsystem(uuid string) (*NVMESubsystem, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/nvme/subsystems/%s", uuid), url.Values{"fields": {"*,hosts"}}), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subsystem := NVMESubsystem{}
+
+	err = json.Unmarshal(body, &subsystem)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &subsystem, nil
+}
+
+// UpdateNVMESubsystem modifies the subsystem comment, hosts are changed with
+// AddNVMESubsystemHost and RemoveNVMESubsystemHost
+func (c *Client) UpdateNVMESubsystem(subsystem *NVMESubsystem) (*NVMESubsystem, error) {
+
+	req_body, err := json.Marshal(NVMESubsystem{Comment: subsystem.Comment})
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/nvme/subsystems/%s", subsystem.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetNVMESubsystem(subsystem.UUID)
+}
+
+// DeleteNVMESubsystem deletes a subsystem along with its hosts and namespace
+// maps
+func (c *Client) DeleteNVMESubsystem(subsystem *NVMESubsystem) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/nvme/subsystems/%s", subsystem.UUID), url.Values{"allow_delete_while_mapped": {"true"}, "allow_delete_with_hosts": {"true"}}), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) AddNVMESubsystemHost(subsystem_uuid string, nqn string) error {
+
+	req_body, err := json.Marshal(NVMESubsystemHost{NQN: nqn})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL(fmt.Sprintf("/protocols/nvme/subsystems/%s/hosts", subsystem_uuid), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) RemoveNVMESubsystemHost(subsystem_uuid string, nqn string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/nvme/subsystems/%s/hosts/%s", subsystem_uuid, url.PathEscape(nqn)), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetNVMESubsystemMaps returns the namespaces mapped to a subsystem
+func (c *Client) GetNVMESubsystemMaps(subsystem_uuid string) ([]NVMESubsystemMap, error) {
+
+	return GetCollection[NVMESubsystemMap](c, "/protocols/nvme/subsystem-maps", &CollectionQuery{
+		Query:  url.Values{"subsystem.uuid": []string{subsystem_uuid}},
+		Fields: []string{"namespace"},
+	})
+}
+
+func (c *Client) CreateNVMESubsystemMap(subsystem_map *NVMESubsystemMap) error {
+
+	req_body, err := json.Marshal(subsystem_map)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/nvme/subsystem-maps", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteNVMESubsystemMap(subsystem_uuid string, namespace_uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/nvme/subsystem-maps/%s/%s", subsystem_uuid, namespace_uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package ontaptest
+
+import (
+	"net/http"
+)
+
+// The credentials accepted by the simulator, used by Client and the provider
+// configurations of the acceptance tests
+const (
+	simUsername = "admin"
+	simPassword = "password"
+)
+
+// sessionCookie is the name of the cookie identifying a session
+const sessionCookie = "sessionid"
+
+// authenticate checks the session cookie of req, or its credentials when it
+// has none, in which case a new session is opened. Like ONTAP, a request with
+// an unknown or expired session is refused even if it has credentials. It
+// answers 401 and returns false when req isn't authenticated.
+func (s *Simulator) authenticate(w http.ResponseWriter, req *http.Request) bool {
+	if cookie, err := req.Cookie(sessionCookie); err == nil {
+		if s.sessions[cookie.Value] {
+			return true
+		}
+
+		writeError(w, http.StatusUnauthorized, "6691623", "The session has expired")
+		return false
+	}
+
+	username, password, ok := req.BasicAuth()
+	if !ok || username != simUsername || password != simPassword {
+		writeError(w, http.StatusUnauthorized, "6691623", "User is not authorized")
+		return false
+	}
+
+	s.logins++
+
+	session := s.newUUID()
+	s.sessions[session] = true
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: session, Path: "/", Secure: true, HttpOnly: true})
+
+	return true
+}
+
+// Logins returns the number of requests authenticated with credentials rather
+// than with a session cookie
+func (s *Simulator) Logins() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.logins
+}
+
+// ExpireSessions ends the sessions opened so far, the following requests
+// with their cookie are refused
+func (s *Simulator) ExpireSessions() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sessions = map[string]bool{}
+}
@@ -0,0 +1,75 @@
+// Package ontaptest records ONTAP REST exchanges to fixture files and replays
+// them with an httptest server, so that the client and the provider can be
+// tested without a cluster.
+//
+// Tests call NewClient with the name of a fixture in testdata. Fixtures are
+// replayed by default, and recorded against a real cluster when ONTAP_RECORD
+// is set, using ONTAP_HOSTNAME, ONTAP_USERNAME and ONTAP_PASSWORD.
+//
+// Provider acceptance tests run against a Simulator instead, an in-memory
+// ONTAP REST API whose state changes with the requests it receives.
+package ontaptest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Exchange is a request sent to ONTAP and the response it returned
+type Exchange struct {
+	Method string `json:"method"`
+	// URI is the path and query of the request, i.e.
+	// /api/svm/svms?fields=%2A&name=svm1
+	URI          string          `json:"uri"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	Status       int             `json:"status"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+}
+
+// Cassette is the list of exchanges of a test, in the order they were sent
+type Cassette struct {
+	Exchanges []Exchange `json:"exchanges"`
+}
+
+// LoadCassette reads a cassette from a fixture file
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := Cassette{}
+
+	err = json.Unmarshal(content, &cassette)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to a fixture file, creating its directory
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
+
+// FixturePath returns the path of the fixture name in the testdata directory
+// of the package under test
+func FixturePath(name string) string {
+	return filepath.Join("testdata", name+".json")
+}
@@ -0,0 +1,237 @@
+package ontaptest
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// newCluster returns the record of the cluster, managed by a single cluster
+// management interface
+func newCluster() simRecord {
+	return simRecord{
+		"uuid": "00000000-0000-4000-8000-000000000000",
+		"name": "simulator",
+		"version": simRecord{
+			"full":       "NetApp Release 9.11.1: ontaptest simulator",
+			"generation": 9,
+			"major":      11,
+			"minor":      1,
+		},
+		"location": "lab",
+		"contact":  "storage@example.com",
+		"management_interfaces": []interface{}{
+			simRecord{"uuid": "00000000-0000-4000-8000-000000000001", "name": "cluster_mgmt", "ip": simRecord{"address": "192.0.2.10"}},
+		},
+		"dns_domains":  []interface{}{"example.com"},
+		"name_servers": []interface{}{"192.0.2.53", "192.0.2.54"},
+		"ntp_servers":  []interface{}{"ntp1.example.com", "ntp2.example.com"},
+		"timezone":     simRecord{"name": "Etc/UTC"},
+	}
+}
+
+// SetVersion sets the ONTAP release run by the cluster and its nodes, 9.11.1
+// by default
+func (s *Simulator) SetVersion(generation int, major int, minor int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	version := simRecord{
+		"full":       fmt.Sprintf("NetApp Release %d.%d.%d: ontaptest simulator", generation, major, minor),
+		"generation": generation,
+		"major":      major,
+		"minor":      minor,
+	}
+
+	s.cluster["version"] = version
+	for _, node := range s.nodes.records {
+		node["version"] = copyRecord(version)
+	}
+}
+
+// fieldReleases are the fields introduced after ONTAP 9.6 by path, with the
+// release introducing them, which older releases reject as unknown
+var fieldReleases = map[string]map[string][3]int{
+	"storage/qtrees": {"qos_policy": {9, 8, 0}},
+}
+
+// checkFieldReleases rejects the fields of a request on path, requested or
+// set in body, that the release run by the cluster doesn't know
+func (s *Simulator) checkFieldReleases(path string, query url.Values, body simRecord) error {
+	for prefix, releases := range fieldReleases {
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+
+		for _, field := range strings.Split(query.Get("fields"), ",") {
+			top, _, _ := strings.Cut(field, ".")
+			if release, ok := releases[top]; ok && !s.runs(release) {
+				return badRequest("262197", "The value %q is invalid for field \"fields\"", field)
+			}
+		}
+
+		for field := range body {
+			if release, ok := releases[field]; ok && !s.runs(release) {
+				return badRequest("262173", "Unexpected argument %q.", field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runs returns whether the cluster runs release or a later one
+func (s *Simulator) runs(release [3]int) bool {
+	version, _ := s.cluster["version"].(simRecord)
+
+	running := [3]int{}
+	for i, field := range []string{"generation", "major", "minor"} {
+		running[i], _ = version[field].(int)
+	}
+
+	for i := range running {
+		if running[i] != release[i] {
+			return running[i] > release[i]
+		}
+	}
+	return true
+}
+
+// addNodeEndpoints serves the nodes of the cluster, two HA pairs that can't
+// be changed
+func (s *Simulator) addNodeEndpoints() {
+	s.nodes = s.addEndpoint(&simEndpoint{
+		path:       "cluster/nodes",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			return nil, badRequest("3", "Nodes can't be added to the simulator")
+		},
+		update: func(record simRecord, body simRecord) error {
+			return badRequest("3", "Nodes can't be changed in the simulator")
+		},
+		remove: func(record simRecord) error {
+			return badRequest("3", "Nodes can't be removed from the simulator")
+		},
+	})
+
+	for pair := 0; pair < 2; pair++ {
+		first := s.newNode(2*pair + 1)
+		second := s.newNode(2*pair + 2)
+
+		setField(first, "ha.partners", []interface{}{simRecord{"uuid": second["uuid"], "name": second["name"]}})
+		setField(second, "ha.partners", []interface{}{simRecord{"uuid": first["uuid"], "name": first["name"]}})
+
+		s.nodes.records = append(s.nodes.records, first, second)
+	}
+}
+
+// addAggregateEndpoints serves the aggregates of the nodes, an SSD aggregate
+// on each node of the first HA pair and an HDD aggregate on each node of the
+// second, that can't be changed
+func (s *Simulator) addAggregateEndpoints() {
+	s.aggregates = s.addEndpoint(&simEndpoint{
+		path:       "storage/aggregates",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			return nil, badRequest("3", "Aggregates can't be added to the simulator")
+		},
+		update: func(record simRecord, body simRecord) error {
+			return badRequest("3", "Aggregates can't be changed in the simulator")
+		},
+		remove: func(record simRecord) error {
+			return badRequest("3", "Aggregates can't be removed from the simulator")
+		},
+	})
+
+	const TiB = 1024 * 1024 * 1024 * 1024
+
+	s.aggregates.records = append(s.aggregates.records,
+		s.newAggregate("aggr1_01", "simulator-01", "ssd", "raid_dp", 1*TiB, TiB/4),
+		s.newAggregate("aggr1_02", "simulator-02", "ssd", "raid_dp", 1*TiB, 3*TiB/4),
+		s.newAggregate("aggr1_03", "simulator-03", "hdd", "raid_tec", 4*TiB, 1*TiB),
+		s.newAggregate("aggr1_04", "simulator-04", "hdd", "raid_tec", 4*TiB, 0),
+	)
+}
+
+// newAggregate returns the online aggregate name of the node named node,
+// using used bytes of size
+func (s *Simulator) newAggregate(name string, node string, storage_type string, raid_type string, size int64, used int64) simRecord {
+	owner := s.nodes.find(func(r simRecord) bool { return r["name"] == node })
+
+	disk_class := "solid_state"
+	if storage_type == "hdd" {
+		disk_class = "capacity"
+	}
+
+	return simRecord{
+		"uuid":  s.newUUID(),
+		"name":  name,
+		"node":  simRecord{"uuid": owner["uuid"], "name": owner["name"]},
+		"state": "online",
+		"space": simRecord{
+			"block_storage": simRecord{
+				"size":      float64(size),
+				"used":      float64(used),
+				"available": float64(size - used),
+			},
+		},
+		"block_storage": simRecord{
+			"storage_type": storage_type,
+			"primary": simRecord{
+				"raid_type":  raid_type,
+				"disk_class": disk_class,
+				"disk_count": float64(8),
+			},
+		},
+	}
+}
+
+// newNode returns the healthy node number n of the cluster
+func (s *Simulator) newNode(n int) simRecord {
+	return simRecord{
+		"uuid":          s.newUUID(),
+		"name":          fmt.Sprintf("simulator-%02d", n),
+		"model":         "SIMBOX",
+		"serial_number": fmt.Sprintf("4082368-50-%d", n),
+		"system_id":     fmt.Sprintf("40823685%02d", n),
+		"location":      "",
+		"version": simRecord{
+			"full":       "NetApp Release 9.11.1: ontaptest simulator",
+			"generation": 9,
+			"major":      11,
+			"minor":      1,
+		},
+		"uptime":     float64(86400),
+		"state":      "up",
+		"membership": "member",
+		"ha": simRecord{
+			"enabled":  true,
+			"takeover": simRecord{"state": "not_attempted"},
+			"giveback": simRecord{"state": "nothing_to_giveback"},
+		},
+		"controller": simRecord{"over_temperature": "normal"},
+	}
+}
+
+// haPair returns the node named name and its HA partners, or nil when there
+// is no such node
+func (s *Simulator) haPair(name string) []simRecord {
+	node := s.nodes.find(func(r simRecord) bool { return r["name"] == name })
+	if node == nil {
+		return nil
+	}
+
+	pair := []simRecord{{"uuid": node["uuid"], "name": node["name"]}}
+
+	ha, _ := node["ha"].(simRecord)
+	partners, _ := ha["partners"].([]interface{})
+	for _, partner := range partners {
+		if ref, ok := partner.(simRecord); ok {
+			pair = append(pair, ref)
+		}
+	}
+
+	return pair
+}
@@ -0,0 +1,466 @@
+package ontaptest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// simEndpoint serves a collection and its records. Records are addressed by
+// appending the values of their key fields to the collection path.
+type simEndpoint struct {
+	// path of the collection, a {field} segment matches any value and
+	// selects the records with this value, i.e.
+	// storage/volumes/{volume.uuid}/snapshots
+	path string
+	// recordKeys are the fields identifying a record in its URL
+	recordKeys []string
+
+	collection *simCollection
+
+	// async endpoints answer changes with a job, others answer 201 Created
+	// with the created record when return_records is true
+	async bool
+	// secrets are the fields only returned when the record is created, i.e.
+	// generated passphrases
+	secrets []string
+
+	// create validates the body of a POST and returns the new record, the
+	// body is stored as is when nil
+	create func(body simRecord) (simRecord, error)
+	// update validates the body of a PATCH and applies it to record, the
+	// body is merged in record when nil
+	update func(record simRecord, body simRecord) error
+	// remove validates the DELETE of record, every record can be deleted
+	// when nil
+	remove func(record simRecord) error
+}
+
+// simMemberEndpoint serves the members of a record, stored in a list field
+// of the record, i.e. the initiators of an igroup. Members are added with a
+// POST on the endpoint path and removed with a DELETE on the path followed by
+// their key.
+type simMemberEndpoint struct {
+	// path of the members, a {field} segment matches the parent record with
+	// this value, i.e. protocols/san/igroups/{uuid}/initiators
+	path   string
+	parent *simCollection
+	// field is the list field of the parent record holding the members
+	field string
+	// key is the member field identifying it in its URL
+	key string
+
+	// add validates the body of a POST and returns the members to add, the
+	// body is added as is when nil
+	add func(parent simRecord, body simRecord) ([]simRecord, error)
+}
+
+// simError is an ONTAP error, answered with status
+type simError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *simError) Error() string {
+	return e.message
+}
+
+func badRequest(code string, format string, args ...interface{}) error {
+	return &simError{status: http.StatusBadRequest, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func conflict(code string, format string, args ...interface{}) error {
+	return &simError{status: http.StatusConflict, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// addEndpoint serves a new collection at path and returns it
+func (s *Simulator) addEndpoint(endpoint *simEndpoint) *simCollection {
+	if endpoint.collection == nil {
+		endpoint.collection = &simCollection{keys: endpoint.recordKeys}
+	}
+
+	s.endpoints = append(s.endpoints, endpoint)
+
+	return endpoint.collection
+}
+
+// addMemberEndpoint serves the members of the records of a collection
+func (s *Simulator) addMemberEndpoint(endpoint *simMemberEndpoint) {
+	s.memberEndpoints = append(s.memberEndpoints, endpoint)
+}
+
+// match returns the values of the {field} segments of the endpoint path and
+// the values of the record keys when segments address the endpoint
+func (e *simEndpoint) match(segments []string) (simRecord, []string, bool) {
+	template := strings.Split(e.path, "/")
+
+	if len(segments) < len(template) {
+		return nil, nil, false
+	}
+
+	captured := simRecord{}
+	for i, part := range template {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			captured[strings.Trim(part, "{}")] = segments[i]
+			continue
+		}
+		if part != segments[i] {
+			return nil, nil, false
+		}
+	}
+
+	keys := segments[len(template):]
+	if len(keys) != 0 && len(keys) != len(e.recordKeys) {
+		return nil, nil, false
+	}
+
+	return captured, keys, true
+}
+
+// serveEndpoint answers req if it addresses a declared endpoint, and returns
+// false otherwise
+func (s *Simulator) serveEndpoint(w http.ResponseWriter, req *http.Request, segments []string, query url.Values, body simRecord) bool {
+	for _, endpoint := range s.endpoints {
+		captured, keys, ok := endpoint.match(segments)
+		if !ok {
+			continue
+		}
+
+		in_scope := func(r simRecord) bool {
+			for field, value := range captured {
+				if lookup(r, field) != value {
+					return false
+				}
+			}
+			return true
+		}
+
+		if len(keys) == 0 {
+			s.serveEndpointCollection(w, req, endpoint, query, body, captured, in_scope)
+			return true
+		}
+
+		match := func(r simRecord) bool {
+			for i, field := range endpoint.recordKeys {
+				if lookup(r, field) != keys[i] {
+					return false
+				}
+			}
+			return in_scope(r)
+		}
+
+		s.serveEndpointRecord(w, req, endpoint, query, body, match)
+		return true
+	}
+
+	return false
+}
+
+// serveMemberEndpoint answers req if it addresses the members of a record,
+// and returns false otherwise
+func (s *Simulator) serveMemberEndpoint(w http.ResponseWriter, req *http.Request, segments []string, body simRecord) bool {
+	for _, endpoint := range s.memberEndpoints {
+		template := strings.Split(endpoint.path, "/")
+
+		if len(segments) != len(template) && len(segments) != len(template)+1 {
+			continue
+		}
+
+		captured := simRecord{}
+		matched := true
+		for i, part := range template {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				captured[strings.Trim(part, "{}")] = segments[i]
+				continue
+			}
+			if part != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		parent := endpoint.parent.find(func(r simRecord) bool {
+			for field, value := range captured {
+				if lookup(r, field) != value {
+					return false
+				}
+			}
+			return true
+		})
+		if parent == nil {
+			writeNotFound(w)
+			return true
+		}
+
+		members, _ := parent[endpoint.field].([]interface{})
+
+		switch {
+		case len(segments) == len(template) && req.Method == "POST":
+			added := []simRecord{body}
+			if endpoint.add != nil {
+				var err error
+				added, err = endpoint.add(parent, body)
+
+				if err != nil {
+					writeSimError(w, err)
+					return true
+				}
+			}
+
+			for _, member := range added {
+				if findMember(members, endpoint.key, lookup(member, endpoint.key)) < 0 {
+					members = append(members, member)
+				}
+			}
+			parent[endpoint.field] = members
+
+			writeJSON(w, http.StatusCreated, simRecord{})
+
+		case len(segments) == len(template)+1 && req.Method == "DELETE":
+			i := findMember(members, endpoint.key, segments[len(template)])
+			if i < 0 {
+				writeNotFound(w)
+				return true
+			}
+
+			parent[endpoint.field] = append(members[:i], members[i+1:]...)
+
+			writeJSON(w, http.StatusOK, simRecord{})
+
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// findMember returns the index of the member of members whose field key has
+// the value value, or -1
+func findMember(members []interface{}, key string, value string) int {
+	for i, member := range members {
+		object, ok := member.(map[string]interface{})
+		if !ok {
+			sim_object, ok := member.(simRecord)
+			if !ok {
+				continue
+			}
+			object = sim_object
+		}
+		if lookup(object, key) == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Simulator) serveEndpointCollection(w http.ResponseWriter, req *http.Request, endpoint *simEndpoint, query url.Values, body simRecord, captured simRecord, in_scope func(simRecord) bool) {
+	switch req.Method {
+	case "GET":
+		scoped := &simCollection{keys: endpoint.collection.keys}
+		for _, record := range endpoint.collection.records {
+			if in_scope(record) {
+				scoped.records = append(scoped.records, record)
+			}
+		}
+		s.writeCollection(w, req, scoped, query)
+
+	case "POST":
+		for field, value := range captured {
+			setField(body, field, value)
+		}
+
+		record := body
+		if endpoint.create != nil {
+			var err error
+			record, err = endpoint.create(body)
+
+			if err != nil {
+				writeSimError(w, err)
+				return
+			}
+		}
+
+		if record["uuid"] == nil && contains(endpoint.recordKeys, "uuid") {
+			record["uuid"] = s.newUUID()
+		}
+
+		created := copyRecord(record)
+		for _, field := range endpoint.secrets {
+			deleteField(record, field)
+		}
+
+		endpoint.collection.records = append(endpoint.collection.records, record)
+
+		if endpoint.async {
+			s.writeJob(w, fmt.Sprintf("POST /api/%s", endpoint.path))
+			return
+		}
+
+		if query.Get("return_records") == "true" {
+			writeJSON(w, http.StatusCreated, simRecord{
+				"num_records": 1,
+				"records":     []map[string]interface{}{created},
+			})
+			return
+		}
+		writeJSON(w, http.StatusCreated, simRecord{})
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+	}
+}
+
+func (s *Simulator) serveEndpointRecord(w http.ResponseWriter, req *http.Request, endpoint *simEndpoint, query url.Values, body simRecord, match func(simRecord) bool) {
+	record := endpoint.collection.find(match)
+
+	if record == nil {
+		writeNotFound(w)
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		s.writeRecord(w, endpoint.collection, record, query)
+		return
+
+	case "PATCH":
+		if endpoint.update != nil {
+			err := endpoint.update(record, body)
+
+			if err != nil {
+				writeSimError(w, err)
+				return
+			}
+		} else {
+			for k, v := range body {
+				record[k] = v
+			}
+		}
+
+	case "DELETE":
+		if endpoint.remove != nil {
+			err := endpoint.remove(record)
+
+			if err != nil {
+				writeSimError(w, err)
+				return
+			}
+		}
+		endpoint.collection.remove(match)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+		return
+	}
+
+	if endpoint.async {
+		s.writeJob(w, fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		return
+	}
+	writeJSON(w, http.StatusOK, simRecord{})
+}
+
+// resolveRef replaces the reference at field of body, by name or UUID, with
+// the name and UUID of the record of collection it designates
+func resolveRef(body simRecord, field string, collection *simCollection) error {
+	ref, ok := body[field].(map[string]interface{})
+	if !ok {
+		if nested, ok := body[field].(simRecord); ok {
+			ref = nested
+		} else {
+			return badRequest("262179", "Missing value for field %q", field)
+		}
+	}
+
+	record := collection.find(func(r simRecord) bool {
+		if uuid, ok := ref["uuid"].(string); ok && uuid != "" {
+			return r["uuid"] == uuid
+		}
+		return ref["name"] != nil && r["name"] == ref["name"]
+	})
+
+	if record == nil {
+		return badRequest("2", "The %s %v was not found", field, ref)
+	}
+
+	body[field] = simRecord{"uuid": record["uuid"], "name": record["name"]}
+
+	return nil
+}
+
+// requireFields returns an error when a field of fields isn't set in body
+func requireFields(body simRecord, fields ...string) error {
+	for _, field := range fields {
+		if lookup(body, field) == "" {
+			return badRequest("262179", "Missing value for field %q", field)
+		}
+	}
+	return nil
+}
+
+// setField sets the value at the dotted path field of record, creating the
+// intermediate objects
+func setField(record simRecord, field string, value interface{}) {
+	keys := strings.Split(field, ".")
+
+	object := map[string]interface{}(record)
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := object[key].(map[string]interface{})
+		if !ok {
+			if sim_nested, ok := object[key].(simRecord); ok {
+				nested = sim_nested
+			} else {
+				nested = map[string]interface{}{}
+				object[key] = nested
+			}
+		}
+		object = nested
+	}
+
+	object[keys[len(keys)-1]] = value
+}
+
+// deleteField removes the value at the dotted path field of record
+func deleteField(record simRecord, field string) {
+	keys := strings.Split(field, ".")
+
+	object := map[string]interface{}(record)
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := object[key].(map[string]interface{})
+		if !ok {
+			sim_nested, ok := object[key].(simRecord)
+			if !ok {
+				return
+			}
+			nested = sim_nested
+		}
+		object = nested
+	}
+
+	delete(object, keys[len(keys)-1])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func writeSimError(w http.ResponseWriter, err error) {
+	if sim_err, ok := err.(*simError); ok {
+		writeError(w, sim_err.status, sim_err.code, sim_err.message)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, "1", err.Error())
+}
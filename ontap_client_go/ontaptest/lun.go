@@ -0,0 +1,150 @@
+package ontaptest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// addLUNEndpoints serves the LUNs, their path must be in an existing volume
+// of their SVM
+func (s *Simulator) addLUNEndpoints() {
+	s.luns = s.addEndpoint(&simEndpoint{
+		path:       "storage/luns",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name", "os_type", "space.size")
+
+			if err != nil {
+				return nil, err
+			}
+
+			volume, logical_unit, err := s.locateInVolume(s.luns, body)
+
+			if err != nil {
+				return nil, err
+			}
+			body["location"] = simRecord{"logical_unit": logical_unit, "volume": volume}
+
+			s.next++
+			body["serial_number"] = fmt.Sprintf("wCVoRB%06d", s.next)
+			body["enabled"] = true
+			body["status"] = simRecord{"state": "online", "mapped": false}
+			setField(body, "space.used", float64(0))
+			if lookup(body, "space.guarantee.requested") == "" {
+				setField(body, "space.guarantee.requested", false)
+			}
+			setField(body, "space.guarantee.reserved", lookup(body, "space.guarantee.requested") == "true")
+
+			return body, setQOSPolicy(body, body["qos_policy"])
+		},
+		update: func(record simRecord, body simRecord) error {
+			if name, ok := body["name"]; ok && name != record["name"] {
+				volume, logical_unit, err := s.locateInVolume(s.luns, simRecord{"svm": record["svm"], "name": name})
+
+				if err != nil {
+					return err
+				}
+
+				if volume["uuid"] != lookup(record, "location.volume.uuid") {
+					return badRequest("5374875", "A LUN can only be moved within its volume")
+				}
+				record["name"] = name
+				record["location"] = simRecord{"logical_unit": logical_unit, "volume": volume}
+			}
+
+			if size := lookup(body, "space.size"); size != "" {
+				value, err := strconv.ParseFloat(size, 64)
+
+				if err != nil {
+					return badRequest("262185", "Invalid value %q for field \"space.size\"", size)
+				}
+				setField(record, "space.size", value)
+			}
+			if requested := lookup(body, "space.guarantee.requested"); requested != "" {
+				setField(record, "space.guarantee.requested", requested == "true")
+				setField(record, "space.guarantee.reserved", requested == "true")
+			}
+			if comment, ok := body["comment"]; ok {
+				record["comment"] = comment
+			}
+			if qos_policy, ok := body["qos_policy"]; ok {
+				return setQOSPolicy(record, qos_policy)
+			}
+
+			return nil
+		},
+		remove: func(record simRecord) error {
+			if lookup(record, "status.mapped") == "true" {
+				return conflict("5374785", "LUN %s is mapped to an initiator group", record["name"])
+			}
+			return nil
+		},
+	})
+}
+
+// locateInVolume returns the volume and the name in the volume of the LUN
+// or namespace of collection at the path of body, i.e. /vol/vol1/lun1, and
+// returns an error when the path is used or its volume doesn't exist
+func (s *Simulator) locateInVolume(collection *simCollection, body simRecord) (simRecord, string, error) {
+	name, _ := body["name"].(string)
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 || parts[0] != "" || parts[1] != "vol" || parts[3] == "" {
+		return nil, "", badRequest("5374860", "Invalid path %q", name)
+	}
+
+	volume := s.volumes.find(func(r simRecord) bool {
+		return r["name"] == parts[2] && lookup(r, "svm.uuid") == lookup(body, "svm.uuid")
+	})
+	if volume == nil {
+		return nil, "", badRequest("5374858", "Volume %s doesn't exist in SVM %s", parts[2], lookup(body, "svm.name"))
+	}
+
+	if s.findInSVM(collection, body, name) != nil {
+		return nil, "", conflict("5374863", "%s already exists", name)
+	}
+
+	return simRecord{"uuid": volume["uuid"], "name": volume["name"]}, parts[3], nil
+}
+
+// setQOSPolicy applies the policy reference qos_policy to record, the policy
+// named none detaches the current one
+func setQOSPolicy(record simRecord, qos_policy interface{}) error {
+	ref, _ := qos_policy.(map[string]interface{})
+	if ref == nil {
+		delete(record, "qos_policy")
+		return nil
+	}
+
+	if ref["name"] == "none" {
+		delete(record, "qos_policy")
+		return nil
+	}
+
+	if ref["name"] == nil {
+		return badRequest("262179", "Missing value for field \"qos_policy.name\"")
+	}
+
+	record["qos_policy"] = simRecord{"name": ref["name"]}
+
+	return nil
+}
+
+// LUN returns a copy of the LUN at path in the SVM svm_name, or nil when it
+// doesn't exist
+func (s *Simulator) LUN(svm_name string, path string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.luns.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == path
+	}))
+}
@@ -0,0 +1,238 @@
+package ontaptest
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// addNVMEEndpoints serves the NVMe services, namespaces, subsystems and
+// subsystem maps. Like ONTAP, a namespace is mapped to a single subsystem
+// and mapped namespaces can't be deleted.
+func (s *Simulator) addNVMEEndpoints() {
+	s.nvmeServices = s.addEndpoint(&simEndpoint{
+		path:       "protocols/nvme/services",
+		recordKeys: []string{"svm.uuid"},
+		collection: &simCollection{keys: []string{"svm"}},
+		create: func(body simRecord) (simRecord, error) {
+			return body, s.createSANService(s.nvmeServices, body, "NVMe")
+		},
+		update: func(record simRecord, body simRecord) error {
+			if enabled, ok := body["enabled"]; ok {
+				record["enabled"] = enabled
+			}
+			return nil
+		},
+		remove: func(record simRecord) error {
+			return removeSANService(record, "NVMe")
+		},
+	})
+
+	s.namespaces = s.addEndpoint(&simEndpoint{
+		path:       "storage/namespaces",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name", "os_type", "space.size")
+
+			if err != nil {
+				return nil, err
+			}
+
+			volume, namespace, err := s.locateInVolume(s.namespaces, body)
+
+			if err != nil {
+				return nil, err
+			}
+			body["location"] = simRecord{"namespace": namespace, "volume": volume}
+
+			switch lookup(body, "space.block_size") {
+			case "":
+				setField(body, "space.block_size", float64(4096))
+			case "512", "4096":
+			default:
+				return nil, badRequest("72089705", "Invalid block size %s", lookup(body, "space.block_size"))
+			}
+			setField(body, "space.used", float64(0))
+			body["status"] = simRecord{"state": "online", "mapped": false}
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if name, ok := body["name"]; ok && name != record["name"] {
+				volume, namespace, err := s.locateInVolume(s.namespaces, simRecord{"svm": record["svm"], "name": name})
+
+				if err != nil {
+					return err
+				}
+
+				if volume["uuid"] != lookup(record, "location.volume.uuid") {
+					return badRequest("72090019", "A namespace can only be moved within its volume")
+				}
+				record["name"] = name
+				record["location"] = simRecord{"namespace": namespace, "volume": volume}
+			}
+
+			if size := lookup(body, "space.size"); size != "" {
+				value, err := strconv.ParseFloat(size, 64)
+
+				if err != nil {
+					return badRequest("262185", "Invalid value %q for field \"space.size\"", size)
+				}
+				setField(record, "space.size", value)
+			}
+			if comment, ok := body["comment"]; ok {
+				record["comment"] = comment
+			}
+
+			return nil
+		},
+		remove: func(record simRecord) error {
+			if lookup(record, "status.mapped") == "true" {
+				return conflict("72090028", "Namespace %s is mapped to a subsystem", record["name"])
+			}
+			return nil
+		},
+	})
+
+	s.subsystems = s.addEndpoint(&simEndpoint{
+		path:       "protocols/nvme/subsystems",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name", "os_type")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.findInSVM(s.subsystems, body, body["name"]) != nil {
+				return nil, conflict("72089771", "Subsystem %s already exists", body["name"])
+			}
+
+			s.next++
+			body["target_nqn"] = fmt.Sprintf("nqn.1992-08.com.netapp:sn.%d:subsystem.%s", s.next, body["name"])
+			body["serial_number"] = fmt.Sprintf("wCVoRBnvme%06d", s.next)
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			for field := range body {
+				if field != "comment" {
+					return badRequest("262186", "Field %q can't be modified", field)
+				}
+			}
+			record["comment"] = body["comment"]
+
+			return nil
+		},
+		remove: func(record simRecord) error {
+			// The subsystem is deleted with its maps, the client always
+			// allows it
+			in_subsystem := func(r simRecord) bool { return lookup(r, "subsystem.uuid") == record["uuid"] }
+			for subsystem_map := s.subsystemMaps.find(in_subsystem); subsystem_map != nil; subsystem_map = s.subsystemMaps.find(in_subsystem) {
+				s.unmapNamespace(subsystem_map)
+				s.subsystemMaps.remove(in_subsystem)
+			}
+			return nil
+		},
+	})
+
+	s.addMemberEndpoint(&simMemberEndpoint{
+		path:   "protocols/nvme/subsystems/{uuid}/hosts",
+		parent: s.subsystems,
+		field:  "hosts",
+		key:    "nqn",
+		add: func(subsystem simRecord, body simRecord) ([]simRecord, error) {
+			err := requireFields(body, "nqn")
+
+			if err != nil {
+				return nil, err
+			}
+
+			return []simRecord{{"nqn": body["nqn"]}}, nil
+		},
+	})
+
+	s.subsystemMaps = s.addEndpoint(&simEndpoint{
+		path:       "protocols/nvme/subsystem-maps",
+		recordKeys: []string{"subsystem.uuid", "namespace.uuid"},
+		collection: &simCollection{keys: []string{"svm", "subsystem", "namespace"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			subsystem := s.subsystems.find(func(r simRecord) bool {
+				return r["uuid"] == lookup(body, "subsystem.uuid") || (lookup(r, "svm.uuid") == lookup(body, "svm.uuid") && r["name"] == lookup(body, "subsystem.name"))
+			})
+			if subsystem == nil {
+				return nil, badRequest("72090001", "Subsystem %v doesn't exist", body["subsystem"])
+			}
+			namespace := s.findInSVM(s.namespaces, body, lookup(body, "namespace.name"))
+			if namespace == nil {
+				return nil, badRequest("72090002", "Namespace %s doesn't exist", lookup(body, "namespace.name"))
+			}
+
+			if lookup(namespace, "status.mapped") == "true" {
+				return nil, conflict("72090003", "Namespace %s is already mapped to a subsystem", namespace["name"])
+			}
+
+			body["subsystem"] = simRecord{"uuid": subsystem["uuid"], "name": subsystem["name"]}
+			body["namespace"] = simRecord{"uuid": namespace["uuid"], "name": namespace["name"]}
+			setField(namespace, "status.mapped", true)
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			return badRequest("3", "Subsystem maps can't be modified")
+		},
+		remove: func(record simRecord) error {
+			s.unmapNamespace(record)
+			return nil
+		},
+	})
+}
+
+// unmapNamespace marks the namespace of subsystem_map as not mapped
+func (s *Simulator) unmapNamespace(subsystem_map simRecord) {
+	namespace := s.namespaces.find(func(r simRecord) bool { return r["uuid"] == lookup(subsystem_map, "namespace.uuid") })
+	if namespace != nil {
+		setField(namespace, "status.mapped", false)
+	}
+}
+
+// NVMESubsystem returns a copy of the subsystem named name in the SVM
+// svm_name, or nil when it doesn't exist
+func (s *Simulator) NVMESubsystem(svm_name string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.subsystems.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == name
+	}))
+}
+
+// NVMENamespace returns a copy of the namespace at path in the SVM svm_name,
+// or nil when it doesn't exist
+func (s *Simulator) NVMENamespace(svm_name string, path string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.namespaces.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == path
+	}))
+}
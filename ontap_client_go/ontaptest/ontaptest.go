@@ -0,0 +1,98 @@
+package ontaptest
+
+import (
+	"os"
+	"testing"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Recording returns true when fixtures are recorded against a real cluster
+// instead of being replayed
+func Recording() bool {
+	return os.Getenv("ONTAP_RECORD") != ""
+}
+
+// NewClient returns a client for the test using the fixture name. When
+// recording, the client talks to the cluster and the fixture is written at
+// the end of the test if it succeeded, otherwise the fixture is replayed.
+func NewClient(t testing.TB, name string) *ontap.Client {
+	t.Helper()
+
+	path := FixturePath(name)
+
+	if Recording() {
+		return newRecordingClient(t, path)
+	}
+
+	cassette, err := LoadCassette(path)
+
+	if err != nil {
+		t.Fatalf("unable to load fixture %s, got error: %s", path, err)
+	}
+
+	server := NewReplayServer(t, cassette)
+
+	host := server.Host()
+	username := "admin"
+	password := "password"
+
+	client, err := ontap.NewClient(&host, &username, &password, true)
+
+	if err != nil {
+		t.Fatalf("unable to create client, got error: %s", err)
+	}
+
+	return client
+}
+
+// Client returns a client for the test talking to the simulator, created
+// with options
+func (s *Simulator) Client(t testing.TB, options ...ontap.ClientOption) *ontap.Client {
+	t.Helper()
+
+	host := s.Host()
+	username := simUsername
+	password := simPassword
+
+	client, err := ontap.NewClient(&host, &username, &password, true, options...)
+
+	if err != nil {
+		t.Fatalf("unable to create client, got error: %s", err)
+	}
+
+	return client
+}
+
+func newRecordingClient(t testing.TB, path string) *ontap.Client {
+	host := os.Getenv("ONTAP_HOSTNAME")
+	username := os.Getenv("ONTAP_USERNAME")
+	password := os.Getenv("ONTAP_PASSWORD")
+
+	if host == "" || username == "" || password == "" {
+		t.Fatal("ONTAP_HOSTNAME, ONTAP_USERNAME and ONTAP_PASSWORD must be set to record fixtures")
+	}
+
+	client, err := ontap.NewClient(&host, &username, &password, true)
+
+	if err != nil {
+		t.Fatalf("unable to create client, got error: %s", err)
+	}
+
+	recorder := NewRecorder(client.HTTPClient.Transport)
+	client.HTTPClient.Transport = recorder
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			return
+		}
+
+		err := recorder.Cassette().Save(path)
+
+		if err != nil {
+			t.Errorf("unable to save fixture %s, got error: %s", path, err)
+		}
+	})
+
+	return client
+}
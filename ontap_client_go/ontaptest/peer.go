@@ -0,0 +1,210 @@
+package ontaptest
+
+import (
+	"fmt"
+	"time"
+)
+
+// addPeerEndpoints serves the cluster and SVM peers. Cluster peers created
+// with a passphrase are available at once, generated passphrases are only
+// returned on creation.
+func (s *Simulator) addPeerEndpoints() {
+	s.clusterPeers = s.addEndpoint(&simEndpoint{
+		path:       "cluster/peers",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		secrets:    []string{"authentication.passphrase"},
+		create: func(body simRecord) (simRecord, error) {
+			authentication, _ := body["authentication"].(map[string]interface{})
+			if authentication == nil {
+				return nil, badRequest("262179", "Missing value for field \"authentication\"")
+			}
+
+			s.next++
+			remote, _ := body["remote"].(map[string]interface{})
+			if remote == nil {
+				remote = map[string]interface{}{}
+				body["remote"] = remote
+			}
+
+			if authentication["generate_passphrase"] == true {
+				authentication["passphrase"] = fmt.Sprintf("ontaptest-passphrase-%d", s.next)
+				if authentication["expiry_time"] == nil {
+					authentication["expiry_time"] = time.Now().Add(time.Hour).Format(ontapTimeFormat)
+				}
+				authentication["state"] = "pending"
+				body["status"] = simRecord{"state": "unidentified"}
+			} else {
+				err := requireFields(body, "authentication.passphrase", "remote.ip_addresses")
+
+				if err != nil {
+					return nil, err
+				}
+
+				remote["name"] = fmt.Sprintf("remote%d", s.next)
+				authentication["state"] = "ok"
+				body["status"] = simRecord{"state": "available"}
+			}
+			delete(authentication, "generate_passphrase")
+
+			err := normalizeTime(authentication, "expiry_time")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if body["name"] == nil {
+				body["name"] = remote["name"]
+				if body["name"] == nil {
+					body["name"] = fmt.Sprintf("Clus_%d", s.next)
+				}
+			}
+			if body["ipspace"] == nil {
+				body["ipspace"] = simRecord{"name": "Default"}
+			}
+
+			encryption, _ := body["encryption"].(map[string]interface{})
+			if encryption == nil {
+				encryption = map[string]interface{}{"proposed": "tls_psk"}
+				body["encryption"] = encryption
+			}
+			encryption["state"] = encryption["proposed"]
+
+			if body["peer_applications"] == nil {
+				body["peer_applications"] = []interface{}{"snapmirror", "flexcache"}
+			}
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			for _, field := range []string{"name", "peer_applications"} {
+				if value, ok := body[field]; ok {
+					record[field] = value
+				}
+			}
+
+			if remote, ok := body["remote"].(map[string]interface{}); ok {
+				record_remote, _ := record["remote"].(map[string]interface{})
+				if record_remote == nil {
+					record_remote = map[string]interface{}{}
+					record["remote"] = record_remote
+				}
+				record_remote["ip_addresses"] = remote["ip_addresses"]
+			}
+
+			if encryption, ok := body["encryption"].(map[string]interface{}); ok {
+				record["encryption"] = simRecord{
+					"proposed": encryption["proposed"],
+					"state":    encryption["proposed"],
+				}
+			}
+
+			return nil
+		},
+	})
+
+	s.svmPeers = s.addEndpoint(&simEndpoint{
+		path:       "svm/peers",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name", "svm", "peer"}},
+		async:      true,
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "peer.svm.name", "applications")
+
+			if err != nil {
+				return nil, err
+			}
+
+			// Peering with an SVM of the same cluster is accepted at once
+			body["state"] = "initiated"
+			if lookup(body, "peer.cluster.name") == "" {
+				peer := body["peer"].(map[string]interface{})
+
+				peer_svm := simRecord{"svm": peer["svm"]}
+				err = resolveRef(peer_svm, "svm", s.svms)
+
+				if err != nil {
+					return nil, err
+				}
+
+				peer["svm"] = peer_svm["svm"]
+				body["state"] = "peered"
+			}
+
+			if body["name"] == nil {
+				body["name"] = lookup(body, "peer.svm.name")
+			}
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if applications, ok := body["applications"]; ok {
+				record["applications"] = applications
+			}
+
+			if state, ok := body["state"].(string); ok && state != "" {
+				if record["state"] != "pending" && record["state"] != state {
+					return badRequest("26345578", "Cannot change the state of a relationship in state %s to %s", record["state"], state)
+				}
+				record["state"] = state
+			}
+
+			return nil
+		},
+	})
+}
+
+// AddSVMPeer creates a pending peer relationship between the SVM svm_name
+// and the SVM peer_svm_name of the cluster peer_cluster, as if it was
+// created from the peer cluster, and returns its UUID
+func (s *Simulator) AddSVMPeer(svm_name string, peer_svm_name string, peer_cluster string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	svm := s.svms.find(func(r simRecord) bool { return r["name"] == svm_name })
+	if svm == nil {
+		panic(fmt.Sprintf("ontaptest: no svm %s", svm_name))
+	}
+
+	uuid := s.newUUID()
+
+	s.svmPeers.records = append(s.svmPeers.records, simRecord{
+		"uuid":  uuid,
+		"name":  peer_svm_name,
+		"svm":   simRecord{"uuid": svm["uuid"], "name": svm_name},
+		"state": "pending",
+		"peer": simRecord{
+			"svm":     simRecord{"name": peer_svm_name},
+			"cluster": simRecord{"name": peer_cluster},
+		},
+		"applications": []interface{}{"snapmirror"},
+	})
+
+	return uuid
+}
+
+// ClusterPeer returns a copy of the cluster peer named name, or nil when it
+// doesn't exist
+func (s *Simulator) ClusterPeer(name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.clusterPeers.find(func(r simRecord) bool { return r["name"] == name }))
+}
+
+// SVMPeer returns a copy of the peer relationship between the SVM svm_name
+// and the SVM peer_svm_name, or nil when it doesn't exist
+func (s *Simulator) SVMPeer(svm_name string, peer_svm_name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.svmPeers.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && lookup(r, "peer.svm.name") == peer_svm_name
+	}))
+}
@@ -0,0 +1,112 @@
+package ontaptest
+
+// addQOSEndpoints serves the QoS policies. Like ONTAP, a policy is either
+// fixed or adaptive, capacity_shared can't be changed and policies applied
+// to qtrees or LUNs can't be deleted.
+func (s *Simulator) addQOSEndpoints() {
+	s.qosPolicies = s.addEndpoint(&simEndpoint{
+		path:       "storage/qos/policies",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		async:      true,
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.findInSVM(s.qosPolicies, body, body["name"]) != nil {
+				return nil, conflict("8454147", "Policy group %s already exists", body["name"])
+			}
+
+			switch {
+			case body["fixed"] != nil && body["adaptive"] != nil:
+				return nil, badRequest("8454292", "A policy can't be both fixed and adaptive")
+			case body["fixed"] != nil:
+				for _, field := range []string{"min_throughput_iops", "max_throughput_iops", "min_throughput_mbps", "max_throughput_mbps"} {
+					if lookup(body, "fixed."+field) == "" {
+						setField(body, "fixed."+field, float64(0))
+					}
+				}
+				if lookup(body, "fixed.capacity_shared") == "" {
+					setField(body, "fixed.capacity_shared", false)
+				}
+			case body["adaptive"] != nil:
+				err = requireFields(body, "adaptive.expected_iops", "adaptive.peak_iops")
+
+				if err != nil {
+					return nil, err
+				}
+
+				defaults := simRecord{
+					"absolute_min_iops":        float64(75),
+					"expected_iops_allocation": "allocated_space",
+					"peak_iops_allocation":     "used_space",
+					"block_size":               "any",
+				}
+				for field, value := range defaults {
+					if lookup(body, "adaptive."+field) == "" {
+						setField(body, "adaptive."+field, value)
+					}
+				}
+			default:
+				return nil, badRequest("8454291", "Either fixed or adaptive must be set")
+			}
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if name, ok := body["name"]; ok && name != record["name"] {
+				if s.findInSVM(s.qosPolicies, record, name) != nil {
+					return conflict("8454147", "Policy group %s already exists", name)
+				}
+				record["name"] = name
+			}
+
+			for _, kind := range []string{"fixed", "adaptive"} {
+				limits, ok := body[kind].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if record[kind] == nil {
+					return badRequest("8454293", "The policy %s isn't %s", record["name"], kind)
+				}
+				for field, value := range limits {
+					if kind == "fixed" && field == "capacity_shared" && lookup(record, "fixed.capacity_shared") != lookup(body, "fixed.capacity_shared") {
+						return badRequest("8454294", "capacity_shared can't be modified")
+					}
+					setField(record, kind+"."+field, value)
+				}
+			}
+
+			return nil
+		},
+		remove: func(record simRecord) error {
+			applied := func(r simRecord) bool {
+				return lookup(r, "svm.uuid") == lookup(record, "svm.uuid") && lookup(r, "qos_policy.name") == record["name"]
+			}
+			if s.qtrees.find(applied) != nil || s.luns.find(applied) != nil {
+				return conflict("8454186", "Policy group %s is applied to storage objects", record["name"])
+			}
+			return nil
+		},
+	})
+}
+
+// QOSPolicy returns a copy of the QoS policy named name in the SVM svm_name,
+// or nil when it doesn't exist
+func (s *Simulator) QOSPolicy(svm_name string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.qosPolicies.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == name
+	}))
+}
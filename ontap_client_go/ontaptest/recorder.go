@@ -0,0 +1,97 @@
+package ontaptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// Recorder is an http.RoundTripper sending requests with Transport and
+// recording the exchanges. Headers aren't recorded, and bodies are redacted
+// with ontap.RedactBody so fixtures don't hold credentials or secrets.
+type Recorder struct {
+	Transport http.RoundTripper
+
+	mutex    sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a recorder sending requests with transport
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	return &Recorder{Transport: transport}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	request_body := []byte{}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		request_body = body
+	}
+
+	res, err := r.Transport.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response_body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(response_body))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cassette.Exchanges = append(r.cassette.Exchanges, Exchange{
+		Method:       req.Method,
+		URI:          req.URL.RequestURI(),
+		RequestBody:  redactedJSON(request_body),
+		Status:       res.StatusCode,
+		ResponseBody: redactedJSON(response_body),
+	})
+
+	return res, nil
+}
+
+// Cassette returns the exchanges recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cassette := Cassette{Exchanges: append([]Exchange{}, r.cassette.Exchanges...)}
+
+	return &cassette
+}
+
+// redactedJSON returns body redacted, as a JSON string when it isn't JSON
+func redactedJSON(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	redacted := []byte(ontap.RedactBody(body))
+
+	if json.Valid(redacted) {
+		return redacted
+	}
+
+	quoted, _ := json.Marshal(string(redacted))
+
+	return quoted
+}
@@ -0,0 +1,116 @@
+package ontaptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ReplayServer is an HTTPS server answering the requests of a cassette in
+// order. A request that doesn't match the next exchange, and exchanges not
+// requested by the end of the test, fail the test.
+type ReplayServer struct {
+	*httptest.Server
+
+	t        testing.TB
+	mutex    sync.Mutex
+	cassette *Cassette
+	next     int
+}
+
+// NewReplayServer starts a server replaying cassette, which is closed at the
+// end of the test
+func NewReplayServer(t testing.TB, cassette *Cassette) *ReplayServer {
+	s := &ReplayServer{
+		t:        t,
+		cassette: cassette,
+	}
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serve))
+
+	t.Cleanup(func() {
+		s.Close()
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if remaining := len(s.cassette.Exchanges) - s.next; remaining > 0 {
+			next := s.cassette.Exchanges[s.next]
+			t.Errorf("%d recorded requests were not sent, next one is %s %s", remaining, next.Method, next.URI)
+		}
+	})
+
+	return s
+}
+
+// Host returns the host and port of the server, to be used as the client
+// hostname
+func (s *ReplayServer) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+func (s *ReplayServer) serve(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+
+	if err != nil {
+		s.fail(w, "unable to read request body: %s", err)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.next >= len(s.cassette.Exchanges) {
+		s.fail(w, "unexpected request %s %s, all recorded requests were sent", req.Method, req.URL.RequestURI())
+		return
+	}
+
+	exchange := s.cassette.Exchanges[s.next]
+
+	if req.Method != exchange.Method || req.URL.RequestURI() != exchange.URI {
+		s.fail(w, "unexpected request %s %s, expected %s %s", req.Method, req.URL.RequestURI(), exchange.Method, exchange.URI)
+		return
+	}
+
+	if !sameJSON(redactedJSON(body), exchange.RequestBody) {
+		s.fail(w, "unexpected body for %s %s: %s, expected %s", req.Method, req.URL.RequestURI(), body, exchange.RequestBody)
+		return
+	}
+
+	s.next++
+
+	if len(exchange.ResponseBody) > 0 {
+		w.Header().Set("Content-Type", "application/hal+json")
+	}
+	w.WriteHeader(exchange.Status)
+	w.Write(exchange.ResponseBody)
+}
+
+func (s *ReplayServer) fail(w http.ResponseWriter, format string, args ...interface{}) {
+	s.t.Errorf(format, args...)
+	http.Error(w, "ontaptest: request doesn't match the recorded exchanges", http.StatusInternalServerError)
+}
+
+// sameJSON compares a redacted request body with the recorded one, ignoring
+// formatting and the order of fields
+func sameJSON(sent json.RawMessage, recorded json.RawMessage) bool {
+	if len(sent) == 0 || len(recorded) == 0 {
+		return len(sent) == 0 && len(recorded) == 0
+	}
+
+	var sent_value, recorded_value interface{}
+
+	if json.Unmarshal(sent, &sent_value) != nil || json.Unmarshal(recorded, &recorded_value) != nil {
+		return bytes.Equal(sent, recorded)
+	}
+
+	sent_json, _ := json.Marshal(sent_value)
+	recorded_json, _ := json.Marshal(recorded_value)
+
+	return bytes.Equal(sent_json, recorded_json)
+}
@@ -0,0 +1,192 @@
+package ontaptest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// addS3Endpoints serves the S3 services of the SVMs, their buckets and users.
+// Like ONTAP, buckets and users require the service of their SVM, which can't
+// be deleted while they exist, and secret keys are only returned when users
+// are created.
+func (s *Simulator) addS3Endpoints() {
+	s.s3Services = s.addEndpoint(&simEndpoint{
+		path:       "protocols/s3/services",
+		recordKeys: []string{"svm.uuid"},
+		collection: &simCollection{keys: []string{"svm", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.s3Service(body) != nil {
+				return nil, conflict("92405789", "The S3 server already exists on SVM %s", lookup(body, "svm.name"))
+			}
+
+			defaults := simRecord{
+				"enabled":          true,
+				"is_http_enabled":  false,
+				"is_https_enabled": true,
+				"port":             float64(80),
+				"secure_port":      float64(443),
+			}
+			for field, value := range defaults {
+				if body[field] == nil {
+					body[field] = value
+				}
+			}
+
+			return body, nil
+		},
+		remove: func(record simRecord) error {
+			in_svm := func(r simRecord) bool { return lookup(r, "svm.uuid") == lookup(record, "svm.uuid") }
+			if s.s3Buckets.find(in_svm) != nil || s.s3Users.find(in_svm) != nil {
+				return conflict("92405790", "The S3 server of SVM %s still has buckets or users", lookup(record, "svm.name"))
+			}
+			return nil
+		},
+	})
+
+	s.s3Buckets = s.addEndpoint(&simEndpoint{
+		path:       "protocols/s3/buckets",
+		recordKeys: []string{"svm.uuid", "uuid"},
+		collection: &simCollection{keys: []string{"svm", "uuid", "name"}},
+		async:      true,
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.s3Service(body) == nil {
+				return nil, badRequest("92405863", "The S3 server doesn't exist on SVM %s", lookup(body, "svm.name"))
+			}
+
+			name, _ := body["name"].(string)
+			if len(name) < 3 || len(name) > 63 || strings.ToLower(name) != name {
+				return nil, badRequest("92405782", "Invalid bucket name %q", name)
+			}
+
+			if s.findInSVM(s.s3Buckets, body, name) != nil {
+				return nil, conflict("92405778", "Bucket %s already exists", name)
+			}
+
+			if body["size"] == nil {
+				body["size"] = float64(800 * 1024 * 1024 * 1024)
+			}
+			if body["versioning_state"] == nil {
+				body["versioning_state"] = "disabled"
+			}
+			body["logical_used_size"] = float64(0)
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if body["versioning_state"] == "disabled" && record["versioning_state"] != "disabled" {
+				return badRequest("92405894", "Versioning of bucket %s can't be disabled once enabled", record["name"])
+			}
+
+			for _, field := range []string{"size", "comment", "versioning_state", "policy"} {
+				if value, ok := body[field]; ok {
+					record[field] = value
+				}
+			}
+			return nil
+		},
+	})
+
+	s.s3Users = s.addEndpoint(&simEndpoint{
+		path:       "protocols/s3/services/{svm.uuid}/users",
+		recordKeys: []string{"name"},
+		collection: &simCollection{keys: []string{"svm", "name"}},
+		secrets:    []string{"secret_key"},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.s3Service(body) == nil {
+				return nil, badRequest("92405863", "The S3 server doesn't exist on SVM %s", lookup(body, "svm.name"))
+			}
+
+			if s.findInSVM(s.s3Users, body, body["name"]) != nil {
+				return nil, conflict("92405804", "User %s already exists", body["name"])
+			}
+
+			s.next++
+			body["access_key"] = fmt.Sprintf("SIMACCESSKEY%08d", s.next)
+			body["secret_key"] = fmt.Sprintf("simulator_secret_key_%08d", s.next)
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			for field := range body {
+				if field != "comment" {
+					return badRequest("262186", "Field %q can't be modified", field)
+				}
+			}
+			record["comment"] = body["comment"]
+
+			return nil
+		},
+	})
+}
+
+// s3Service returns the S3 service of the SVM of body, or nil
+func (s *Simulator) s3Service(body simRecord) simRecord {
+	return s.s3Services.find(func(r simRecord) bool { return lookup(r, "svm.uuid") == lookup(body, "svm.uuid") })
+}
+
+// S3Service returns a copy of the S3 service of the SVM svm_name, or nil when
+// it doesn't exist
+func (s *Simulator) S3Service(svm_name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.s3Services.find(func(r simRecord) bool { return lookup(r, "svm.name") == svm_name }))
+}
+
+// S3Bucket returns a copy of the bucket named name in the SVM svm_name, or
+// nil when it doesn't exist
+func (s *Simulator) S3Bucket(svm_name string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.s3Buckets.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == name
+	}))
+}
+
+// S3User returns a copy of the user named name of the S3 service of the SVM
+// svm_name, or nil when it doesn't exist
+func (s *Simulator) S3User(svm_name string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.s3Users.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == name
+	}))
+}
@@ -0,0 +1,491 @@
+package ontaptest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// addSANEndpoints serves the initiator groups and the LUN maps. Like ONTAP,
+// mapped LUNs and igroups can't be deleted and reporting nodes are added by
+// HA pair.
+func (s *Simulator) addSANEndpoints() {
+	s.igroups = s.addEndpoint(&simEndpoint{
+		path:       "protocols/san/igroups",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name", "os_type")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.findInSVM(s.igroups, body, body["name"]) != nil {
+				return nil, conflict("5374738", "Initiator group %s already exists", body["name"])
+			}
+
+			if body["protocol"] == nil {
+				body["protocol"] = "mixed"
+			}
+
+			initiators, _ := body["initiators"].([]interface{})
+			children, _ := body["igroups"].([]interface{})
+			if len(initiators) > 0 && len(children) > 0 {
+				return nil, badRequest("5374927", "An initiator group can't have both initiators and nested initiator groups")
+			}
+
+			for i, child := range children {
+				child_ref, _ := child.(map[string]interface{})
+
+				nested := s.findInSVM(s.igroups, body, child_ref["name"])
+				if nested == nil {
+					return nil, badRequest("5374852", "Initiator group %v doesn't exist", child_ref["name"])
+				}
+				children[i] = simRecord{"uuid": nested["uuid"], "name": nested["name"]}
+			}
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if name, ok := body["name"]; ok && name != record["name"] {
+				if s.findInSVM(s.igroups, record, name) != nil {
+					return conflict("5374738", "Initiator group %s already exists", name)
+				}
+				record["name"] = name
+			}
+
+			for _, field := range []string{"os_type", "comment"} {
+				if value, ok := body[field]; ok {
+					record[field] = value
+				}
+			}
+
+			// An empty portset name unbinds the portset
+			if portset, ok := body["portset"].(map[string]interface{}); ok {
+				if portset["name"] == "" {
+					delete(record, "portset")
+				} else {
+					record["portset"] = simRecord{"name": portset["name"]}
+				}
+			}
+
+			return nil
+		},
+		remove: func(record simRecord) error {
+			mapped := s.lunMaps.find(func(r simRecord) bool { return lookup(r, "igroup.uuid") == record["uuid"] })
+			if mapped != nil {
+				return conflict("5374785", "Initiator group %s is mapped to LUN %s", record["name"], lookup(mapped, "lun.name"))
+			}
+
+			parent := s.igroups.find(func(r simRecord) bool {
+				children, _ := r["igroups"].([]interface{})
+				return findMember(children, "uuid", record["uuid"].(string)) >= 0
+			})
+			if parent != nil {
+				return conflict("5374928", "Initiator group %s is nested in %s", record["name"], parent["name"])
+			}
+
+			return nil
+		},
+	})
+
+	s.addMemberEndpoint(&simMemberEndpoint{
+		path:   "protocols/san/igroups/{uuid}/initiators",
+		parent: s.igroups,
+		field:  "initiators",
+		key:    "name",
+		add: func(igroup simRecord, body simRecord) ([]simRecord, error) {
+			err := requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if children, _ := igroup["igroups"].([]interface{}); len(children) > 0 {
+				return nil, badRequest("5374927", "An initiator group can't have both initiators and nested initiator groups")
+			}
+
+			return []simRecord{{"name": body["name"]}}, nil
+		},
+	})
+
+	s.addMemberEndpoint(&simMemberEndpoint{
+		path:   "protocols/san/igroups/{uuid}/igroups",
+		parent: s.igroups,
+		field:  "igroups",
+		key:    "uuid",
+		add: func(igroup simRecord, body simRecord) ([]simRecord, error) {
+			if initiators, _ := igroup["initiators"].([]interface{}); len(initiators) > 0 {
+				return nil, badRequest("5374927", "An initiator group can't have both initiators and nested initiator groups")
+			}
+
+			nested := s.findInSVM(s.igroups, igroup, body["name"])
+			if nested == nil {
+				return nil, badRequest("5374852", "Initiator group %v doesn't exist", body["name"])
+			}
+
+			return []simRecord{{"uuid": nested["uuid"], "name": nested["name"]}}, nil
+		},
+	})
+
+	s.lunMaps = s.addEndpoint(&simEndpoint{
+		path:       "protocols/san/lun-maps",
+		recordKeys: []string{"lun.uuid", "igroup.uuid"},
+		collection: &simCollection{keys: []string{"svm", "lun", "igroup", "logical_unit_number"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "lun.name", "igroup.name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			lun := s.findInSVM(s.luns, body, lookup(body, "lun.name"))
+			if lun == nil {
+				return nil, badRequest("5374858", "LUN %s doesn't exist", lookup(body, "lun.name"))
+			}
+			igroup := s.findInSVM(s.igroups, body, lookup(body, "igroup.name"))
+			if igroup == nil {
+				return nil, badRequest("5374852", "Initiator group %s doesn't exist", lookup(body, "igroup.name"))
+			}
+			body["lun"] = simRecord{"uuid": lun["uuid"], "name": lun["name"]}
+			body["igroup"] = simRecord{"uuid": igroup["uuid"], "name": igroup["name"]}
+
+			in_igroup := func(r simRecord) bool { return lookup(r, "igroup.uuid") == igroup["uuid"] }
+			if s.lunMaps.find(func(r simRecord) bool { return in_igroup(r) && lookup(r, "lun.uuid") == lun["uuid"] }) != nil {
+				return nil, conflict("5374922", "LUN %s is already mapped to %s", lun["name"], igroup["name"])
+			}
+
+			if body["logical_unit_number"] == nil {
+				number := 0
+				for s.lunMaps.find(func(r simRecord) bool {
+					return in_igroup(r) && lookup(r, "logical_unit_number") == strconv.Itoa(number)
+				}) != nil {
+					number++
+				}
+				body["logical_unit_number"] = float64(number)
+			} else if s.lunMaps.find(func(r simRecord) bool {
+				return in_igroup(r) && lookup(r, "logical_unit_number") == lookup(body, "logical_unit_number")
+			}) != nil {
+				return nil, conflict("5374876", "Logical unit number %s is already used in %s", lookup(body, "logical_unit_number"), igroup["name"])
+			}
+
+			// LUNs are reported by the HA pair owning their volume
+			nodes := []interface{}{}
+			for _, node := range s.haPair("simulator-01") {
+				nodes = append(nodes, node)
+			}
+			body["reporting_nodes"] = nodes
+
+			setField(lun, "status.mapped", true)
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			return badRequest("3", "LUN maps can't be modified")
+		},
+		remove: func(record simRecord) error {
+			lun := s.luns.find(func(r simRecord) bool { return r["uuid"] == lookup(record, "lun.uuid") })
+			other := s.lunMaps.find(func(r simRecord) bool {
+				return lookup(r, "lun.uuid") == lookup(record, "lun.uuid") && lookup(r, "igroup.uuid") != lookup(record, "igroup.uuid")
+			})
+			if lun != nil && other == nil {
+				setField(lun, "status.mapped", false)
+			}
+			return nil
+		},
+	})
+
+	s.addMemberEndpoint(&simMemberEndpoint{
+		path:   "protocols/san/lun-maps/{lun.uuid}/{igroup.uuid}/reporting-nodes",
+		parent: s.lunMaps,
+		field:  "reporting_nodes",
+		key:    "uuid",
+		add: func(lun_map simRecord, body simRecord) ([]simRecord, error) {
+			name, _ := body["name"].(string)
+
+			pair := s.haPair(name)
+			if pair == nil {
+				return nil, badRequest("5374864", "Node %s doesn't exist", name)
+			}
+
+			return pair, nil
+		},
+	})
+}
+
+// findInSVM returns the record of collection named name in the SVM of
+// scope, or nil
+func (s *Simulator) findInSVM(collection *simCollection, scope simRecord, name interface{}) simRecord {
+	return collection.find(func(r simRecord) bool {
+		return lookup(r, "svm.uuid") == lookup(scope, "svm.uuid") && r["name"] == name
+	})
+}
+
+// IGroup returns a copy of the initiator group named name in the SVM
+// svm_name, or nil when it doesn't exist
+func (s *Simulator) IGroup(svm_name string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.igroups.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["name"] == name
+	}))
+}
+
+// LUNMap returns a copy of the map of the LUN at path to the initiator group
+// igroup_name in the SVM svm_name, or nil when it doesn't exist
+func (s *Simulator) LUNMap(svm_name string, path string, igroup_name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.lunMaps.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && lookup(r, "lun.name") == path && lookup(r, "igroup.name") == igroup_name
+	}))
+}
+
+// addSANServiceEndpoints serves the iSCSI and FC services of the SVMs and the
+// iSCSI credentials. Like ONTAP, running services can't be deleted and the
+// default credentials are created with the iSCSI service. CHAP passwords are
+// never returned.
+func (s *Simulator) addSANServiceEndpoints() {
+	s.iscsiServices = s.addEndpoint(&simEndpoint{
+		path:       "protocols/san/iscsi/services",
+		recordKeys: []string{"svm.uuid"},
+		collection: &simCollection{keys: []string{"svm"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := s.createSANService(s.iscsiServices, body, "iSCSI")
+
+			if err != nil {
+				return nil, err
+			}
+
+			setField(body, "target.name", fmt.Sprintf("iqn.1992-08.com.netapp:sn.%s:vs.%d", strings.ReplaceAll(lookup(body, "svm.uuid"), "-", ""), s.next))
+			if lookup(body, "target.alias") == "" {
+				setField(body, "target.alias", lookup(body, "svm.name"))
+			}
+
+			s.iscsiCredentials.records = append(s.iscsiCredentials.records, simRecord{
+				"svm":                 body["svm"],
+				"initiator":           "default",
+				"authentication_type": "none",
+			})
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if enabled, ok := body["enabled"]; ok {
+				record["enabled"] = enabled
+			}
+			if alias := lookup(body, "target.alias"); alias != "" {
+				setField(record, "target.alias", alias)
+			}
+			return nil
+		},
+		remove: func(record simRecord) error {
+			err := removeSANService(record, "iSCSI")
+
+			if err != nil {
+				return err
+			}
+
+			in_svm := func(r simRecord) bool { return lookup(r, "svm.uuid") == lookup(record, "svm.uuid") }
+			for s.iscsiCredentials.find(in_svm) != nil {
+				s.iscsiCredentials.remove(in_svm)
+			}
+			return nil
+		},
+	})
+
+	s.fcpServices = s.addEndpoint(&simEndpoint{
+		path:       "protocols/san/fcp/services",
+		recordKeys: []string{"svm.uuid"},
+		collection: &simCollection{keys: []string{"svm"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := s.createSANService(s.fcpServices, body, "FC")
+
+			if err != nil {
+				return nil, err
+			}
+
+			setField(body, "target.name", fmt.Sprintf("20:00:00:50:56:b4:%02x:%02x", s.next/256%256, s.next%256))
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if enabled, ok := body["enabled"]; ok {
+				record["enabled"] = enabled
+			}
+			return nil
+		},
+		remove: func(record simRecord) error {
+			return removeSANService(record, "FC")
+		},
+	})
+
+	s.iscsiCredentials = s.addEndpoint(&simEndpoint{
+		path:       "protocols/san/iscsi/credentials",
+		recordKeys: []string{"svm.uuid", "initiator"},
+		collection: &simCollection{keys: []string{"svm", "initiator"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "svm", s.svms)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "initiator", "authentication_type")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.iscsiServices.find(func(r simRecord) bool { return lookup(r, "svm.uuid") == lookup(body, "svm.uuid") }) == nil {
+				return nil, badRequest("5374078", "The iSCSI service doesn't exist on SVM %s", lookup(body, "svm.name"))
+			}
+
+			duplicate := s.iscsiCredentials.find(func(r simRecord) bool {
+				return lookup(r, "svm.uuid") == lookup(body, "svm.uuid") && r["initiator"] == body["initiator"]
+			})
+			if duplicate != nil {
+				return nil, conflict("5374905", "Credentials for initiator %s already exist", body["initiator"])
+			}
+
+			record := simRecord{"svm": body["svm"], "initiator": body["initiator"]}
+
+			return record, s.setISCSIAuthentication(record, body)
+		},
+		update: func(record simRecord, body simRecord) error {
+			if _, ok := body["authentication_type"]; !ok {
+				body["authentication_type"] = record["authentication_type"]
+			}
+			return s.setISCSIAuthentication(record, body)
+		},
+		remove: func(record simRecord) error {
+			if record["initiator"] == "default" {
+				return badRequest("5374906", "The default credentials can't be deleted")
+			}
+			return nil
+		},
+	})
+}
+
+// createSANService validates the creation of the service of protocol in
+// collection for the SVM of body
+func (s *Simulator) createSANService(collection *simCollection, body simRecord, protocol string) error {
+	err := resolveRef(body, "svm", s.svms)
+
+	if err != nil {
+		return err
+	}
+
+	if collection.find(func(r simRecord) bool { return lookup(r, "svm.uuid") == lookup(body, "svm.uuid") }) != nil {
+		return conflict("5374077", "The %s service already exists on SVM %s", protocol, lookup(body, "svm.name"))
+	}
+
+	if body["enabled"] == nil {
+		body["enabled"] = true
+	}
+
+	s.next++
+
+	return nil
+}
+
+func removeSANService(record simRecord, protocol string) error {
+	if record["enabled"] == true {
+		return conflict("5374079", "The %s service must be disabled before it is deleted", protocol)
+	}
+	return nil
+}
+
+// setISCSIAuthentication applies the authentication of body to the
+// credentials record, the CHAP passwords are kept out of the record
+func (s *Simulator) setISCSIAuthentication(record simRecord, body simRecord) error {
+	key := lookup(record, "svm.name") + "/" + lookup(record, "initiator")
+
+	delete(record, "chap")
+	delete(s.chapPasswords, key+"/inbound")
+	delete(s.chapPasswords, key+"/outbound")
+
+	record["authentication_type"] = body["authentication_type"]
+
+	if body["authentication_type"] != "chap" {
+		if body["chap"] != nil {
+			return badRequest("5374910", "CHAP users can only be set with the chap authentication type")
+		}
+		return nil
+	}
+
+	err := requireFields(body, "chap.inbound.user", "chap.inbound.password")
+
+	if err != nil {
+		return err
+	}
+
+	for _, direction := range []string{"inbound", "outbound"} {
+		user := lookup(body, "chap."+direction+".user")
+		if user == "" {
+			continue
+		}
+
+		password := lookup(body, "chap."+direction+".password")
+		if password == "" {
+			return badRequest("262179", "Missing value for field \"chap.%s.password\"", direction)
+		}
+
+		setField(record, "chap."+direction+".user", user)
+		s.chapPasswords[key+"/"+direction] = password
+	}
+
+	return nil
+}
+
+// ISCSICredentials returns a copy of the credentials of initiator in the SVM
+// svm_name, or nil when they don't exist
+func (s *Simulator) ISCSICredentials(svm_name string, initiator string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.iscsiCredentials.find(func(r simRecord) bool {
+		return lookup(r, "svm.name") == svm_name && r["initiator"] == initiator
+	}))
+}
+
+// ISCSIChapPassword returns the CHAP password of initiator in the SVM
+// svm_name, direction is inbound or outbound
+func (s *Simulator) ISCSIChapPassword(svm_name string, initiator string, direction string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.chapPasswords[svm_name+"/"+initiator+"/"+direction]
+}
+
+// SANService returns a copy of the service of protocol, iscsi, fcp or nvme,
+// of the SVM svm_name, or nil when it doesn't exist
+func (s *Simulator) SANService(protocol string, svm_name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection := s.iscsiServices
+	switch protocol {
+	case "fcp":
+		collection = s.fcpServices
+	case "nvme":
+		collection = s.nvmeServices
+	}
+
+	return copyRecord(collection.find(func(r simRecord) bool { return lookup(r, "svm.name") == svm_name }))
+}
@@ -0,0 +1,73 @@
+package ontaptest
+
+// addScheduleEndpoints serves the cluster job schedules
+func (s *Simulator) addScheduleEndpoints() {
+	s.schedules = s.addEndpoint(&simEndpoint{
+		path:       "cluster/schedules",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		create: func(body simRecord) (simRecord, error) {
+			err := requireFields(body, "name", "type")
+
+			if err != nil {
+				return nil, err
+			}
+
+			if s.schedules.find(func(r simRecord) bool { return r["name"] == body["name"] }) != nil {
+				return nil, conflict("458753", "Duplicate schedule name %s", body["name"])
+			}
+
+			normalizeCron(body)
+
+			return body, nil
+		},
+		update: func(record simRecord, body simRecord) error {
+			if body["name"] != nil || body["type"] != nil {
+				return badRequest("262186", "Field \"name\" and \"type\" cannot be modified")
+			}
+
+			// Like ONTAP, omitted cron fields keep their value and empty ones
+			// match every value
+			if cron, ok := body["cron"].(map[string]interface{}); ok {
+				current, ok := record["cron"].(map[string]interface{})
+				if !ok {
+					current = map[string]interface{}{}
+					record["cron"] = current
+				}
+				for field, value := range cron {
+					current[field] = value
+				}
+				normalizeCron(record)
+			}
+			if interval, ok := body["interval"]; ok {
+				record["interval"] = interval
+			}
+
+			return nil
+		},
+	})
+}
+
+// Schedule returns a copy of the schedule named name, or nil when it doesn't
+// exist
+func (s *Simulator) Schedule(name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.schedules.find(func(r simRecord) bool { return r["name"] == name }))
+}
+
+// normalizeCron removes the empty cron fields of record, ONTAP doesn't return
+// the fields matching every value
+func normalizeCron(record simRecord) {
+	cron, ok := record["cron"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for field, value := range cron {
+		if values, ok := value.([]interface{}); ok && len(values) == 0 {
+			delete(cron, field)
+		}
+	}
+}
@@ -0,0 +1,891 @@
+package ontaptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulator is an in-memory ONTAP REST API serving SVMs, volumes and qtrees
+// over HTTPS. Like ONTAP, collections only return identifiers unless fields
+// are requested, queries support the ONTAP operators, long collections are
+// paginated with _links.next, logins open sessions identified by a cookie,
+// fields unknown to the simulated release are rejected and changes are made
+// by jobs answered with 202 Accepted.
+type Simulator struct {
+	*httptest.Server
+
+	// PageSize is the number of records returned per page when max_records
+	// isn't set, all records are returned when 0
+	PageSize int
+
+	// Latency delays the responses, requests being served concurrently
+	// during that time
+	Latency time.Duration
+
+	mutex sync.Mutex
+
+	// inFlight is the number of requests being served, and peak its highest
+	// value
+	inFlight int
+	peak     int
+
+	// requests are the method and URI of the requests served
+	requests []string
+
+	// sessions are the open sessions by cookie value, and logins the number
+	// of requests authenticated with credentials
+	sessions map[string]bool
+	logins   int
+
+	svms    *simCollection
+	volumes *simCollection
+	qtrees  *simCollection
+	jobs    map[string]simRecord
+
+	schedules *simCollection
+	snapshots *simCollection
+
+	relationships      *simCollection
+	snapmirrorPolicies *simCollection
+
+	clusterPeers *simCollection
+	svmPeers     *simCollection
+
+	// cluster is the record of the cluster, served at /api/cluster
+	cluster    simRecord
+	nodes      *simCollection
+	aggregates *simCollection
+
+	luns    *simCollection
+	igroups *simCollection
+	lunMaps *simCollection
+
+	iscsiServices    *simCollection
+	fcpServices      *simCollection
+	iscsiCredentials *simCollection
+	// chapPasswords are the CHAP passwords of the iSCSI credentials, by
+	// <svm>/<initiator>/<direction>
+	chapPasswords map[string]string
+
+	nvmeServices  *simCollection
+	namespaces    *simCollection
+	subsystems    *simCollection
+	subsystemMaps *simCollection
+
+	s3Services *simCollection
+	s3Buckets  *simCollection
+	s3Users    *simCollection
+
+	qosPolicies *simCollection
+
+	// endpoints are the other collections, served by serveEndpoint, and
+	// memberEndpoints the members of their records
+	endpoints       []*simEndpoint
+	memberEndpoints []*simMemberEndpoint
+
+	// next is used to generate UUIDs
+	next int
+}
+
+// simRecord is an ONTAP object, as decoded from JSON
+type simRecord map[string]interface{}
+
+// simCollection holds the records of a collection in creation order
+type simCollection struct {
+	// keys are the fields returned when no fields are requested
+	keys    []string
+	records []simRecord
+}
+
+// NewSimulator starts a simulator with no SVM, it must be closed with Close
+func NewSimulator() *Simulator {
+	s := &Simulator{
+		svms:    &simCollection{keys: []string{"uuid", "name"}},
+		volumes: &simCollection{keys: []string{"uuid", "name"}},
+		qtrees:  &simCollection{keys: []string{"svm", "volume", "id", "name"}},
+		jobs:    map[string]simRecord{},
+
+		sessions: map[string]bool{},
+
+		chapPasswords: map[string]string{},
+	}
+
+	s.cluster = newCluster()
+	s.addNodeEndpoints()
+	s.addAggregateEndpoints()
+	s.addScheduleEndpoints()
+	s.addSnapshotEndpoints()
+	s.addSnapmirrorEndpoints()
+	s.addPeerEndpoints()
+	s.addLUNEndpoints()
+	s.addSANEndpoints()
+	s.addSANServiceEndpoints()
+	s.addNVMEEndpoints()
+	s.addS3Endpoints()
+	s.addQOSEndpoints()
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Host returns the host and port of the simulator, to be used as the
+// provider hostname
+func (s *Simulator) Host() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+// AddSVM creates a running SVM and returns its UUID
+func (s *Simulator) AddSVM(name string) string {
+	return s.AddSVMWithFields(name, nil)
+}
+
+// AddSVMWithFields creates an SVM with fields overriding the defaults, i.e.
+// {"state": "stopped"} or {"nfs": {"enabled": true}}, and returns its UUID
+func (s *Simulator) AddSVMWithFields(name string, fields map[string]interface{}) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	uuid := s.newUUID()
+
+	body := copyRecord(fields)
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+	body["name"] = name
+
+	s.svms.records = append(s.svms.records, s.newSVM(uuid, body))
+
+	return uuid
+}
+
+// AddVolume creates a volume in the SVM svm_uuid and returns its UUID. Like
+// ONTAP, the volume has a default qtree with id 0.
+func (s *Simulator) AddVolume(svm_uuid string, name string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	svm := s.svms.find(func(r simRecord) bool { return r["uuid"] == svm_uuid })
+	if svm == nil {
+		panic(fmt.Sprintf("ontaptest: no svm %s", svm_uuid))
+	}
+
+	uuid := s.newUUID()
+	svm_ref := simRecord{"uuid": svm_uuid, "name": svm["name"]}
+
+	s.volumes.records = append(s.volumes.records, simRecord{
+		"uuid":  uuid,
+		"name":  name,
+		"svm":   svm_ref,
+		"state": "online",
+	})
+
+	s.qtrees.records = append(s.qtrees.records, simRecord{
+		"svm":              svm_ref,
+		"volume":           simRecord{"uuid": uuid, "name": name},
+		"id":               float64(0),
+		"name":             "",
+		"path":             "/" + name,
+		"security_style":   "unix",
+		"unix_permissions": float64(755),
+	})
+
+	return uuid
+}
+
+// SVM returns a copy of the SVM named name, or nil when it doesn't exist
+func (s *Simulator) SVM(name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.svms.find(func(r simRecord) bool { return r["name"] == name }))
+}
+
+// Requests returns the method and URI of the requests served so far, i.e.
+// "GET /api/svm/svms?name=svm1"
+func (s *Simulator) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string{}, s.requests...)
+}
+
+// AddQtree creates a qtree with security_style in the volume volume_uuid
+func (s *Simulator) AddQtree(volume_uuid string, name string, security_style string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err := s.createQtree(simRecord{
+		"volume":         simRecord{"uuid": volume_uuid},
+		"name":           name,
+		"security_style": security_style,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("ontaptest: %s", err))
+	}
+}
+
+// Qtree returns a copy of the qtree named name in the volume volume_uuid, or
+// nil when it doesn't exist
+func (s *Simulator) Qtree(volume_uuid string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.qtrees.find(func(r simRecord) bool {
+		return lookup(r, "volume.uuid") == volume_uuid && r["name"] == name
+	}))
+}
+
+func (s *Simulator) newUUID() string {
+	s.next++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.next)
+}
+
+func (s *Simulator) newSVM(uuid string, body simRecord) simRecord {
+	svm := simRecord{
+		"state":   "running",
+		"subtype": "default",
+		"ipspace": simRecord{"name": "Default"},
+	}
+
+	for k, v := range body {
+		svm[k] = v
+	}
+	svm["uuid"] = uuid
+
+	return svm
+}
+
+// PeakConcurrency returns the highest number of requests served at the same
+// time so far
+func (s *Simulator) PeakConcurrency() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.peak
+}
+
+// handle serves req after the latency of the simulator. Requests are in
+// flight until they are served, which is serialized, so that a request is
+// never counted once its response is sent.
+func (s *Simulator) handle(w http.ResponseWriter, req *http.Request) {
+	s.mutex.Lock()
+	s.inFlight++
+	if s.inFlight > s.peak {
+		s.peak = s.inFlight
+	}
+	latency := s.Latency
+	s.mutex.Unlock()
+
+	time.Sleep(latency)
+
+	s.mutex.Lock()
+	s.inFlight--
+	s.mutex.Unlock()
+
+	s.serve(w, req)
+}
+
+func (s *Simulator) serve(w http.ResponseWriter, req *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", req.Method, req.URL.RequestURI()))
+
+	if !s.authenticate(w, req) {
+		return
+	}
+
+	body := simRecord{}
+
+	content, err := ioutil.ReadAll(req.Body)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "2", err.Error())
+		return
+	}
+
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "2", fmt.Sprintf("invalid JSON body: %s", err))
+			return
+		}
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, "/api"), "/")
+	segments := strings.Split(path, "/")
+	query := req.URL.Query()
+
+	if err := s.checkFieldReleases(path, query, body); err != nil {
+		writeSimError(w, err)
+		return
+	}
+
+	switch {
+	case path == "cluster" && req.Method == "GET":
+		fields := query.Get("fields")
+		if fields == "" {
+			fields = "*"
+		}
+		writeJSON(w, http.StatusOK, project(s.cluster, []string{"uuid", "name"}, fields))
+
+	case len(segments) == 3 && path == "cluster/jobs/"+segments[2] && req.Method == "GET":
+		job, ok := s.jobs[segments[2]]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, job)
+
+	case path == "svm/svms":
+		s.serveSVMs(w, req, query, body)
+
+	case len(segments) == 3 && path == "svm/svms/"+segments[2]:
+		s.serveSVM(w, req, query, body, segments[2])
+
+	case path == "storage/volumes" && req.Method == "GET":
+		s.writeCollection(w, req, s.volumes, query)
+
+	case len(segments) == 3 && path == "storage/volumes/"+segments[2] && req.Method == "GET":
+		volume := s.volumes.find(func(r simRecord) bool { return r["uuid"] == segments[2] })
+		s.writeRecord(w, s.volumes, volume, query)
+
+	case path == "storage/qtrees":
+		s.serveQtrees(w, req, query, body)
+
+	case len(segments) == 4 && path == "storage/qtrees/"+segments[2]+"/"+segments[3]:
+		s.serveQtree(w, req, query, body, segments[2], segments[3])
+
+	case s.serveMemberEndpoint(w, req, segments, body):
+
+	case s.serveEndpoint(w, req, segments, query, body):
+
+	default:
+		writeError(w, http.StatusNotFound, "3", fmt.Sprintf("API not found: %s %s", req.Method, req.URL.Path))
+	}
+}
+
+func (s *Simulator) serveSVMs(w http.ResponseWriter, req *http.Request, query url.Values, body simRecord) {
+	switch req.Method {
+	case "GET":
+		s.writeCollection(w, req, s.svms, query)
+
+	case "POST":
+		name, _ := body["name"].(string)
+		if name == "" {
+			writeError(w, http.StatusBadRequest, "262179", "Missing value for field \"name\"")
+			return
+		}
+		if s.svms.find(func(r simRecord) bool { return r["name"] == name }) != nil {
+			writeError(w, http.StatusConflict, "13434920", fmt.Sprintf("Duplicate SVM name %s", name))
+			return
+		}
+
+		svm := s.newSVM(s.newUUID(), body)
+		s.svms.records = append(s.svms.records, svm)
+
+		s.writeJob(w, fmt.Sprintf("Create %s", name))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+	}
+}
+
+func (s *Simulator) serveSVM(w http.ResponseWriter, req *http.Request, query url.Values, body simRecord, uuid string) {
+	match := func(r simRecord) bool { return r["uuid"] == uuid }
+	svm := s.svms.find(match)
+
+	if svm == nil {
+		writeNotFound(w)
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		s.writeRecord(w, s.svms, svm, query)
+
+	case "PATCH":
+		for k, v := range body {
+			if k != "uuid" {
+				svm[k] = v
+			}
+		}
+		s.writeJob(w, fmt.Sprintf("Modify %s", svm["name"]))
+
+	case "DELETE":
+		s.svms.remove(match)
+		s.writeJob(w, fmt.Sprintf("Delete %s", svm["name"]))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+	}
+}
+
+func (s *Simulator) serveQtrees(w http.ResponseWriter, req *http.Request, query url.Values, body simRecord) {
+	switch req.Method {
+	case "GET":
+		s.writeCollection(w, req, s.qtrees, query)
+
+	case "POST":
+		qtree, err := s.createQtree(body)
+
+		if err != nil {
+			writeSimError(w, err)
+			return
+		}
+
+		s.writeJob(w, fmt.Sprintf("Create %s", qtree["name"]))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+	}
+}
+
+func (s *Simulator) serveQtree(w http.ResponseWriter, req *http.Request, query url.Values, body simRecord, volume_uuid string, id string) {
+	match := func(r simRecord) bool {
+		return lookup(r, "volume.uuid") == volume_uuid && lookup(r, "id") == id
+	}
+	qtree := s.qtrees.find(match)
+
+	if qtree == nil {
+		writeNotFound(w)
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		s.writeRecord(w, s.qtrees, qtree, query)
+
+	case "PATCH":
+		s.updateQtree(qtree, body)
+		s.writeJob(w, fmt.Sprintf("Modify %s", qtree["name"]))
+
+	case "DELETE":
+		s.qtrees.remove(match)
+		s.writeJob(w, fmt.Sprintf("Delete %s", qtree["name"]))
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "3", "method not allowed")
+	}
+}
+
+// createQtree adds the qtree described by body to its volume, with the next
+// free id
+func (s *Simulator) createQtree(body simRecord) (simRecord, error) {
+	volume_uuid := lookup(body, "volume.uuid")
+	volume := s.volumes.find(func(r simRecord) bool { return r["uuid"] == volume_uuid })
+	if volume == nil {
+		return nil, badRequest("917927", "The specified volume %q was not found", volume_uuid)
+	}
+
+	name, _ := body["name"].(string)
+	if name == "" {
+		return nil, badRequest("262179", "Missing value for field \"name\"")
+	}
+
+	id := float64(0)
+	for _, qtree := range s.qtrees.records {
+		if lookup(qtree, "volume.uuid") != volume_uuid {
+			continue
+		}
+		if qtree["name"] == name {
+			return nil, conflict("5242956", "A qtree named %s already exists", name)
+		}
+		if qtree_id, _ := qtree["id"].(float64); qtree_id > id {
+			id = qtree_id
+		}
+	}
+
+	qtree := simRecord{
+		"svm":              volume["svm"],
+		"volume":           simRecord{"uuid": volume_uuid, "name": volume["name"]},
+		"id":               id + 1,
+		"name":             name,
+		"path":             fmt.Sprintf("/%s/%s", volume["name"], name),
+		"security_style":   "unix",
+		"unix_permissions": float64(755),
+	}
+	s.updateQtree(qtree, body)
+
+	s.qtrees.records = append(s.qtrees.records, qtree)
+
+	return qtree, nil
+}
+
+// updateQtree applies the modifiable fields of body to qtree
+func (s *Simulator) updateQtree(qtree simRecord, body simRecord) {
+	if name, ok := body["name"].(string); ok && name != "" {
+		qtree["name"] = name
+		qtree["path"] = fmt.Sprintf("/%s/%s", lookup(qtree, "volume.name"), name)
+	}
+	if style, ok := body["security_style"].(string); ok && style != "" {
+		qtree["security_style"] = style
+	}
+	if permissions, ok := body["unix_permissions"].(float64); ok && permissions != 0 {
+		qtree["unix_permissions"] = permissions
+	}
+	if policy, ok := body["qos_policy"].(map[string]interface{}); ok {
+		if policy["name"] == "none" {
+			delete(qtree, "qos_policy")
+		} else {
+			qtree["qos_policy"] = simRecord{"name": policy["name"]}
+		}
+	}
+}
+
+// writeJob answers a change with 202 Accepted and a job that has already
+// succeeded
+func (s *Simulator) writeJob(w http.ResponseWriter, description string) {
+	uuid := s.newUUID()
+
+	s.jobs[uuid] = simRecord{
+		"uuid":        uuid,
+		"description": description,
+		"state":       "success",
+		"message":     "success",
+		"code":        0,
+	}
+
+	writeJSON(w, http.StatusAccepted, simRecord{
+		"job": simRecord{
+			"uuid": uuid,
+			"_links": simRecord{
+				"self": simRecord{"href": "/api/cluster/jobs/" + uuid},
+			},
+		},
+	})
+}
+
+// writeCollection answers a collection GET with the page of records matching
+// query
+func (s *Simulator) writeCollection(w http.ResponseWriter, req *http.Request, collection *simCollection, query url.Values) {
+	matching := []simRecord{}
+
+	for _, record := range collection.records {
+		if matchesQuery(record, query) {
+			matching = append(matching, record)
+		}
+	}
+
+	if order_by := query.Get("order_by"); order_by != "" {
+		field, direction, _ := strings.Cut(order_by, " ")
+		sort.SliceStable(matching, func(i, j int) bool {
+			if direction == "desc" {
+				return lookup(matching[j], field) < lookup(matching[i], field)
+			}
+			return lookup(matching[i], field) < lookup(matching[j], field)
+		})
+	}
+
+	start, _ := strconv.Atoi(query.Get("start.index"))
+	if start > len(matching) {
+		start = len(matching)
+	}
+
+	page_size := s.PageSize
+	if max_records, err := strconv.Atoi(query.Get("max_records")); err == nil && max_records > 0 {
+		page_size = max_records
+	}
+
+	end := len(matching)
+	if page_size > 0 && start+page_size < end {
+		end = start + page_size
+	}
+
+	records := []simRecord{}
+	for _, record := range matching[start:end] {
+		records = append(records, project(record, collection.keys, query.Get("fields")))
+	}
+
+	links := simRecord{
+		"self": simRecord{"href": req.URL.RequestURI()},
+	}
+
+	if end < len(matching) {
+		next := url.Values{}
+		for k, v := range query {
+			next[k] = v
+		}
+		next.Set("start.index", strconv.Itoa(end))
+		links["next"] = simRecord{"href": fmt.Sprintf("%s?%s", req.URL.Path, next.Encode())}
+	}
+
+	writeJSON(w, http.StatusOK, simRecord{
+		"records":     records,
+		"num_records": len(records),
+		"_links":      links,
+	})
+}
+
+// writeRecord answers a GET on a record, with all its fields unless fields
+// are requested
+func (s *Simulator) writeRecord(w http.ResponseWriter, collection *simCollection, record simRecord, query url.Values) {
+	if record == nil {
+		writeNotFound(w)
+		return
+	}
+
+	fields := query.Get("fields")
+	if fields == "" {
+		fields = "*"
+	}
+
+	writeJSON(w, http.StatusOK, project(record, collection.keys, fields))
+}
+
+func (c *simCollection) find(match func(simRecord) bool) simRecord {
+	for _, record := range c.records {
+		if match(record) {
+			return record
+		}
+	}
+	return nil
+}
+
+func (c *simCollection) remove(match func(simRecord) bool) {
+	for i, record := range c.records {
+		if match(record) {
+			c.records = append(c.records[:i], c.records[i+1:]...)
+			return
+		}
+	}
+}
+
+// project returns the keys of record and the requested fields, a field
+// selecting a nested value returns the whole top level object
+func project(record simRecord, keys []string, fields string) simRecord {
+	projected := simRecord{}
+
+	selected := append([]string{}, keys...)
+	for _, field := range strings.Split(fields, ",") {
+		if field == "*" || field == "**" {
+			return copyRecord(record)
+		}
+		if field != "" {
+			top, _, _ := strings.Cut(field, ".")
+			selected = append(selected, top)
+		}
+	}
+
+	for _, field := range selected {
+		if value, ok := record[field]; ok {
+			projected[field] = value
+		}
+	}
+
+	return projected
+}
+
+// queryParameters are the parameters of a collection GET that aren't filters
+var queryParameters = map[string]bool{
+	"fields":         true,
+	"max_records":    true,
+	"order_by":       true,
+	"return_records": true,
+	"return_timeout": true,
+	"start.index":    true,
+}
+
+func matchesQuery(record simRecord, query url.Values) bool {
+	for field, values := range query {
+		if queryParameters[field] {
+			continue
+		}
+		for _, pattern := range values {
+			if !matchesValue(lookup(record, field), pattern) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesValue matches a value with an ONTAP query pattern, supporting OR
+// (|), negation (!), comparisons, ranges (..), wildcards (*) and quoted
+// literals
+func matchesValue(value string, pattern string) bool {
+	for _, alternative := range splitUnquoted(pattern, '|') {
+		if strings.HasPrefix(alternative, "!") {
+			if !matchesValue(value, alternative[1:]) {
+				return true
+			}
+			continue
+		}
+
+		if matchesComparison(value, alternative) || matchesGlob(value, alternative) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesComparison(value string, pattern string) bool {
+	number, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		return false
+	}
+
+	if min, max, found := strings.Cut(pattern, ".."); found {
+		low, err_low := strconv.ParseFloat(min, 64)
+		high, err_high := strconv.ParseFloat(max, 64)
+		return err_low == nil && err_high == nil && number >= low && number <= high
+	}
+
+	for _, operator := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(pattern, operator) {
+			continue
+		}
+
+		operand, err := strconv.ParseFloat(strings.TrimPrefix(pattern, operator), 64)
+		if err != nil {
+			return false
+		}
+
+		switch operator {
+		case ">=":
+			return number >= operand
+		case "<=":
+			return number <= operand
+		case ">":
+			return number > operand
+		default:
+			return number < operand
+		}
+	}
+
+	return false
+}
+
+// matchesGlob matches value with a pattern where unquoted * matches any
+// sequence of characters
+func matchesGlob(value string, pattern string) bool {
+	parts := []string{""}
+	quoted := false
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && quoted && i+1 < len(pattern):
+			i++
+			parts[len(parts)-1] += string(pattern[i])
+		case pattern[i] == '"':
+			quoted = !quoted
+		case pattern[i] == '*' && !quoted:
+			parts = append(parts, "")
+		default:
+			parts[len(parts)-1] += string(pattern[i])
+		}
+	}
+
+	if len(parts) == 1 {
+		return value == parts[0]
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
+	}
+
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// splitUnquoted splits pattern on separator outside of double quotes
+func splitUnquoted(pattern string, separator byte) []string {
+	parts := []string{}
+	quoted := false
+	start := 0
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && quoted:
+			i++
+		case pattern[i] == '"':
+			quoted = !quoted
+		case pattern[i] == separator && !quoted:
+			parts = append(parts, pattern[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, pattern[start:])
+}
+
+// lookup returns the value at the dotted path field of record as a string,
+// i.e. svm.name
+func lookup(record map[string]interface{}, field string) string {
+	var value interface{} = record
+
+	for _, key := range strings.Split(field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			if nested, ok := value.(simRecord); ok {
+				object = nested
+			} else {
+				return ""
+			}
+		}
+		value = object[key]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// copyRecord returns a deep copy of record through JSON, so that callers
+// can't change the simulator state
+func copyRecord(record simRecord) map[string]interface{} {
+	if record == nil {
+		return nil
+	}
+
+	content, _ := json.Marshal(record)
+
+	copied := map[string]interface{}{}
+	json.Unmarshal(content, &copied)
+
+	return copied
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	writeJSON(w, status, simRecord{
+		"error": simRecord{
+			"message": message,
+			"code":    code,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "4", "entry doesn't exist")
+}
@@ -0,0 +1,197 @@
+package ontaptest
+
+// addSnapmirrorEndpoints serves the SnapMirror relationships and policies.
+// Baseline transfers complete as soon as a relationship is initialized.
+func (s *Simulator) addSnapmirrorEndpoints() {
+	s.relationships = s.addEndpoint(&simEndpoint{
+		path:       "snapmirror/relationships",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "source", "destination"}},
+		async:      true,
+		create: func(body simRecord) (simRecord, error) {
+			err := requireFields(body, "source.path", "destination.path")
+
+			if err != nil {
+				return nil, err
+			}
+
+			duplicate := s.relationships.find(func(r simRecord) bool {
+				return lookup(r, "destination.path") == lookup(body, "destination.path")
+			})
+			if duplicate != nil {
+				return nil, conflict("6619637", "Relationship with destination %s already exists", lookup(body, "destination.path"))
+			}
+
+			if body["policy"] == nil {
+				body["policy"] = simRecord{"name": "MirrorAllSnapshots"}
+			}
+
+			if body["transfer_schedule"] != nil {
+				err = resolveRef(body, "transfer_schedule", s.schedules)
+
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if body["state"] == nil || body["state"] == "snapmirrored" {
+				body["state"] = "snapmirrored"
+				body["lag_time"] = "PT0S"
+			} else {
+				body["state"] = "uninitialized"
+			}
+			body["healthy"] = true
+
+			return body, nil
+		},
+		update: s.updateRelationship,
+	})
+
+	s.snapmirrorPolicies = s.addEndpoint(&simEndpoint{
+		path:       "snapmirror/policies",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		async:      true,
+		create: func(body simRecord) (simRecord, error) {
+			err := requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			body["scope"] = "cluster"
+			if body["svm"] != nil {
+				err = resolveRef(body, "svm", s.svms)
+
+				if err != nil {
+					return nil, err
+				}
+				body["scope"] = "svm"
+			}
+
+			duplicate := s.snapmirrorPolicies.find(func(r simRecord) bool {
+				return r["name"] == body["name"] && lookup(r, "svm.uuid") == lookup(body, "svm.uuid")
+			})
+			if duplicate != nil {
+				return nil, conflict("13048066", "Duplicate policy name %s", body["name"])
+			}
+
+			if body["type"] == nil {
+				body["type"] = "async"
+			}
+			if body["type"] == "sync" && body["sync_type"] == nil {
+				body["sync_type"] = "sync"
+			}
+			if body["network_compression_enabled"] == nil {
+				body["network_compression_enabled"] = false
+			}
+
+			// Like ONTAP, asynchronous policies created without rules
+			// transfer the snapshots created by SnapMirror
+			if body["retention"] == nil && body["type"] == "async" {
+				body["retention"] = []interface{}{
+					simRecord{"label": "sm_created", "count": float64(1)},
+				}
+			}
+
+			return body, s.updateSnapmirrorPolicy(body, body)
+		},
+		update: s.updateSnapmirrorPolicy,
+	})
+}
+
+// updateSnapmirrorPolicy applies the modifiable fields of body to policy, a
+// null transfer schedule removes it
+func (s *Simulator) updateSnapmirrorPolicy(policy simRecord, body simRecord) error {
+	for _, field := range []string{"comment", "network_compression_enabled", "identity_preservation"} {
+		if value, ok := body[field]; ok {
+			policy[field] = value
+		}
+	}
+
+	if rules, ok := body["retention"].([]interface{}); ok {
+		for _, rule := range rules {
+			rule, _ := rule.(map[string]interface{})
+			if rule == nil || rule["creation_schedule"] == nil {
+				continue
+			}
+
+			err := resolveRef(rule, "creation_schedule", s.schedules)
+
+			if err != nil {
+				return err
+			}
+		}
+		policy["retention"] = rules
+	}
+
+	if schedule, ok := body["transfer_schedule"]; ok {
+		if schedule == nil {
+			delete(policy, "transfer_schedule")
+			return nil
+		}
+
+		err := resolveRef(body, "transfer_schedule", s.schedules)
+
+		if err != nil {
+			return err
+		}
+		policy["transfer_schedule"] = body["transfer_schedule"]
+	}
+
+	return nil
+}
+
+// SnapmirrorPolicy returns a copy of the cluster scoped policy named name, or
+// nil when it doesn't exist
+func (s *Simulator) SnapmirrorPolicy(name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.snapmirrorPolicies.find(func(r simRecord) bool {
+		return r["name"] == name && r["scope"] == "cluster"
+	}))
+}
+
+// updateRelationship applies the policy, transfer schedule and state of body
+// to relationship, a null transfer schedule removes it
+func (s *Simulator) updateRelationship(relationship simRecord, body simRecord) error {
+	if policy, ok := body["policy"].(map[string]interface{}); ok {
+		relationship["policy"] = simRecord{"name": policy["name"]}
+	}
+
+	if schedule, ok := body["transfer_schedule"]; ok {
+		if schedule == nil {
+			delete(relationship, "transfer_schedule")
+		} else {
+			err := resolveRef(body, "transfer_schedule", s.schedules)
+
+			if err != nil {
+				return err
+			}
+			relationship["transfer_schedule"] = body["transfer_schedule"]
+		}
+	}
+
+	if state, ok := body["state"].(string); ok {
+		switch state {
+		case "snapmirrored", "broken_off", "paused":
+			relationship["state"] = state
+		default:
+			return badRequest("13303812", "Invalid state %s", state)
+		}
+	}
+
+	return nil
+}
+
+// SnapmirrorRelationship returns a copy of the relationship with the
+// destination path, or nil when it doesn't exist
+func (s *Simulator) SnapmirrorRelationship(destination_path string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.relationships.find(func(r simRecord) bool {
+		return lookup(r, "destination.path") == destination_path
+	}))
+}
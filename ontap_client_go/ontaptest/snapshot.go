@@ -0,0 +1,109 @@
+package ontaptest
+
+import (
+	"time"
+)
+
+// ontapTimeFormat is the format of the timestamps returned by ONTAP
+const ontapTimeFormat = "2006-01-02T15:04:05-07:00"
+
+// addSnapshotEndpoints serves the snapshots of the volumes
+func (s *Simulator) addSnapshotEndpoints() {
+	s.snapshots = s.addEndpoint(&simEndpoint{
+		path:       "storage/volumes/{volume.uuid}/snapshots",
+		recordKeys: []string{"uuid"},
+		collection: &simCollection{keys: []string{"uuid", "name"}},
+		async:      true,
+		create: func(body simRecord) (simRecord, error) {
+			err := resolveRef(body, "volume", s.volumes)
+
+			if err != nil {
+				return nil, err
+			}
+
+			err = requireFields(body, "name")
+
+			if err != nil {
+				return nil, err
+			}
+
+			duplicate := s.snapshots.find(func(r simRecord) bool {
+				return lookup(r, "volume.uuid") == lookup(body, "volume.uuid") && r["name"] == body["name"]
+			})
+			if duplicate != nil {
+				return nil, conflict("1638407", "Snapshot copy %s already exists", body["name"])
+			}
+
+			body["create_time"] = time.Now().Format(ontapTimeFormat)
+			body["state"] = "valid"
+			body["size"] = float64(0)
+
+			return body, normalizeTime(body, "expiry_time")
+		},
+		update: func(record simRecord, body simRecord) error {
+			for _, field := range []string{"name", "comment", "expiry_time", "snapmirror_label"} {
+				if value, ok := body[field]; ok {
+					record[field] = value
+				}
+			}
+
+			return normalizeTime(record, "expiry_time")
+		},
+	})
+}
+
+// AddSnapshot creates a snapshot of the volume volume_uuid created at
+// create_time, an RFC3339 timestamp, and returns its UUID
+func (s *Simulator) AddSnapshot(volume_uuid string, name string, create_time string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	volume := s.volumes.find(func(r simRecord) bool { return r["uuid"] == volume_uuid })
+	if volume == nil {
+		panic("ontaptest: no volume " + volume_uuid)
+	}
+
+	uuid := s.newUUID()
+
+	s.snapshots.records = append(s.snapshots.records, simRecord{
+		"uuid":        uuid,
+		"name":        name,
+		"volume":      simRecord{"uuid": volume_uuid, "name": volume["name"]},
+		"create_time": create_time,
+		"state":       "valid",
+		"size":        float64(0),
+	})
+
+	return uuid
+}
+
+// Snapshot returns a copy of the snapshot named name in the volume
+// volume_uuid, or nil when it doesn't exist
+func (s *Simulator) Snapshot(volume_uuid string, name string) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyRecord(s.snapshots.find(func(r simRecord) bool {
+		return lookup(r, "volume.uuid") == volume_uuid && r["name"] == name
+	}))
+}
+
+// normalizeTime stores the RFC3339 timestamp at field of record with the
+// UTC offset ONTAP returns, i.e. 2022-01-01T00:00:00+00:00 for
+// 2022-01-01T00:00:00Z
+func normalizeTime(record simRecord, field string) error {
+	value, ok := record[field].(string)
+	if !ok || value == "" {
+		return nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return badRequest("262185", "Invalid value %q for field %q", value, field)
+	}
+
+	record[field] = timestamp.Format(ontapTimeFormat)
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+/*
+****************************
+
+	cluster peers
+
+*****************************
+*/
+
+type ClusterPeer struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name             string                     `json:"name,omitempty"`
+	Remote           *ClusterPeerRemote         `json:"remote,omitempty"`
+	Authentication   *ClusterPeerAuthentication `json:"authentication,omitempty"`
+	Encryption       *ClusterPeerEncryption     `json:"encryption,omitempty"`
+	IPSpace          *UUIDRef                   `json:"ipspace,omitempty"`
+	PeerApplications []string                   `json:"peer_applications,omitempty"`
+	Status           *ClusterPeerStatus         `json:"status,omitempty"`
+}
+
+type ClusterPeerRemote struct {
+	Name         string   `json:"name,omitempty"`
+	SerialNumber string   `json:"serial_number,omitempty"`
+	IPAddresses  []string `json:"ip_addresses,omitempty"`
+}
+
+type ClusterPeerAuthentication struct {
+	GeneratePassphrase bool   `json:"generate_passphrase,omitempty"`
+	Passphrase         string `json:"passphrase,omitempty"`
+	ExpiryTime         string `json:"expiry_time,omitempty"`
+	InUse              string `json:"in_use,omitempty"`
+	State              string `json:"state,omitempty"`
+}
+
+type ClusterPeerEncryption struct {
+	Proposed string `json:"proposed,omitempty"`
+	State    string `json:"state,omitempty"`
+}
+
+type ClusterPeerStatus struct {
+	State string `json:"state,omitempty"`
+}
+
+// CreateClusterPeer creates a cluster peer. When a passphrase is generated,
+// it is only returned by this call and set in the result.
+func (c *Client) CreateClusterPeer(peer *ClusterPeer) (*ClusterPeer, error) {
+
+	peer_copy := *peer
+	peer_copy.UUID = ""
+	peer_copy.Status = nil
+
+	req_body, err := json.Marshal(peer_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/cluster/peers", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	peer_result := CollectionResponse[ClusterPeer]{}
+	err = json.Unmarshal(body, &peer_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(peer_result.Records) == 0 {
+		return nil, fmt.Errorf("cluster peer was not returned after creation")
+	}
+
+	created_peer, err := c.GetClusterPeer(peer_result.Records[0].UUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if peer_result.Records[0].Authentication != nil && peer_result.Records[0].Authentication.Passphrase != "" {
+		if created_peer.Authentication == nil {
+			created_peer.Authentication = &ClusterPeerAuthentication{}
+		}
+		created_peer.Authentication.Passphrase = peer_result.Records[0].Authentication.Passphrase
+	}
+
+	return created_peer, nil
+}
+
+func (c *Client) GetClusterPeer(uuid string) (*ClusterPeer, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/cluster/peers/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	peer := ClusterPeer{}
+
+	err = json.Unmarshal(body, &peer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer, nil
+}
+
+func (c *Client) UpdateClusterPeer(peer *ClusterPeer) (*ClusterPeer, error) {
+
+	peer_copy := ClusterPeer{
+		Name:             peer.Name,
+		Remote:           peer.Remote,
+		Encryption:       peer.Encryption,
+		PeerApplications: peer.PeerApplications,
+	}
+
+	req_body, err := json.Marshal(peer_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/cluster/peers/%s", peer.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetClusterPeer(peer.UUID)
+}
+
+func (c *Client) DeleteClusterPeer(peer *ClusterPeer) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/cluster/peers/%s", peer.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/*
+****************************
+
+	svm peers
+
+*****************************
+*/
+
+type SVMPeer struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name         string       `json:"name,omitempty"`
+	SVM          *UUIDRef     `json:"svm,omitempty"`
+	Peer         *SVMPeerPeer `json:"peer,omitempty"`
+	Applications []string     `json:"applications,omitempty"`
+	State        string       `json:"state,omitempty"`
+}
+
+type SVMPeerPeer struct {
+	SVM     UUIDRef  `json:"svm,omitempty"`
+	Cluster *UUIDRef `json:"cluster,omitempty"`
+}
+
+func (c *Client) CreateSVMPeer(peer *SVMPeer) (*SVMPeer, error) {
+
+	peer_copy := *peer
+	peer_copy.UUID = ""
+	peer_copy.State = ""
+
+	req_body, err := json.Marshal(peer_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/svm/peers", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSVMPeerByName(peer.SVM.Name, peer.Peer.SVM.Name)
+}
+
+func (c *Client) GetSVMPeer(uuid string) (*SVMPeer, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/svm/peers/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	peer := SVMPeer{}
+
+	err = json.Unmarshal(body, &peer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer, nil
+}
+
+// GetSVMPeerByName looks up the peer relationship between the local SVM
+// svm_name and the remote SVM peer_svm_name
+func (c *Client) GetSVMPeerByName(svm_name string, peer_svm_name string) (*SVMPeer, error) {
+
+	return GetCollectionRecord[SVMPeer](c, "/svm/peers", &CollectionQuery{
+		Query:  url.Values{"svm.name": []string{QueryLiteral(svm_name)}, "peer.svm.name": []string{QueryLiteral(peer_svm_name)}},
+		Fields: []string{"*"},
+	})
+}
+
+// UpdateSVMPeer modifies the applications of a peer relationship, and its
+// state when set, i.e. peered to accept a pending relationship
+func (c *Client) UpdateSVMPeer(peer *SVMPeer) (*SVMPeer, error) {
+
+	peer_copy := SVMPeer{
+		Applications: peer.Applications,
+		State:        peer.State,
+	}
+
+	req_body, err := json.Marshal(peer_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/svm/peers/%s", peer.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSVMPeer(peer.UUID)
+}
+
+func (c *Client) DeleteSVMPeer(peer *SVMPeer) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/svm/peers/%s", peer.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
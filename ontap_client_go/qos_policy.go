@@ -0,0 +1,150 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type QOSPolicy struct {
+	UUID string `json:"uuid,omitempty"`
+
+	SVM      *UUIDRef           `json:"svm,omitempty"`
+	Name     string             `json:"name,omitempty"`
+	Fixed    *QOSPolicyFixed    `json:"fixed,omitempty"`
+	Adaptive *QOSPolicyAdaptive `json:"adaptive,omitempty"`
+}
+
+// QOSPolicyFixed holds the limits of a fixed policy, a zero value means no
+// limit so limits are always sent to be able to remove them
+type QOSPolicyFixed struct {
+	MinThroughputIOPS int64 `json:"min_throughput_iops"`
+	MaxThroughputIOPS int64 `json:"max_throughput_iops"`
+	MinThroughputMBPS int64 `json:"min_throughput_mbps"`
+	MaxThroughputMBPS int64 `json:"max_throughput_mbps"`
+	CapacityShared    *bool `json:"capacity_shared,omitempty"`
+}
+
+// QOSPolicyAdaptive holds the IOPS per TB of an adaptive policy
+type QOSPolicyAdaptive struct {
+	ExpectedIOPS           int64  `json:"expected_iops"`
+	PeakIOPS               int64  `json:"peak_iops"`
+	AbsoluteMinIOPS        int64  `json:"absolute_min_iops,omitempty"`
+	ExpectedIOPSAllocation string `json:"expected_iops_allocation,omitempty"`
+	PeakIOPSAllocation     string `json:"peak_iops_allocation,omitempty"`
+	BlockSize              string `json:"block_size,omitempty"`
+}
+
+func (c *Client) CreateQOSPolicy(policy *QOSPolicy) (*QOSPolicy, error) {
+
+	policy_copy := *policy
+	policy_copy.UUID = ""
+
+	req_body, err := json.Marshal(policy_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/storage/qos/policies", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Policy creation is a job, the policy is looked up by name once it is
+	// complete
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetQOSPolicyByName(policy.SVM.Name, policy.Name)
+}
+
+func (c *Client) GetQOSPolicy(uuid string) (*QOSPolicy, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/storage/qos/policies/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	policy := QOSPolicy{}
+
+	err = json.Unmarshal(body, &policy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (c *Client) GetQOSPolicyByName(svm_name string, name string) (*QOSPolicy, error) {
+
+	return GetCollectionRecord[QOSPolicy](c, "/storage/qos/policies", &CollectionQuery{
+		Query:  url.Values{"svm.name": []string{QueryLiteral(svm_name)}, "name": []string{QueryLiteral(name)}},
+		Fields: []string{"*"},
+	})
+}
+
+func (c *Client) UpdateQOSPolicy(policy *QOSPolicy) (*QOSPolicy, error) {
+
+	policy_copy := *policy
+	policy_copy.UUID = ""
+	policy_copy.SVM = nil
+
+	// Capacity sharing can't be changed once the policy is created
+	if policy_copy.Fixed != nil {
+		fixed := *policy_copy.Fixed
+		fixed.CapacityShared = nil
+		policy_copy.Fixed = &fixed
+	}
+
+	req_body, err := json.Marshal(policy_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/storage/qos/policies/%s", policy.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetQOSPolicy(policy.UUID)
+}
+
+func (c *Client) DeleteQOSPolicy(uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/storage/qos/policies/%s", uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
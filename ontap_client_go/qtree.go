@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type Qtree struct {
@@ -20,6 +21,33 @@ type Qtree struct {
 	Path           string `json:"path,omitempty"`
 	SecurityStyle  string `json:"security_style,omitempty"`
 	UnixPermission int64  `json:"unix_permissions,omitempty"`
+
+	QOSPolicy *UUIDRef `json:"qos_policy,omitempty"`
+}
+
+// qtreeFields returns the fields requested for qtrees, which are all the
+// provider uses, as collection GETs only return the identifiers by default.
+// ONTAP rejects unknown fields, so qos_policy is only requested from ONTAP
+// 9.8, or when the version wasn't detected.
+func (c *Client) qtreeFields() string {
+	fields := "id,name,svm,volume,path,security_style,unix_permissions"
+
+	if c.Version == nil || c.Version.AtLeast(9, 8, 0) {
+		fields += ",qos_policy"
+	}
+
+	return fields
+}
+
+// fromRest moves the svm and volume references returned by ONTAP to the
+// flattened fields and builds the <VolumeUUID>/<QtreeID> identifier
+func (qtree *Qtree) fromRest() {
+	qtree.UUID = fmt.Sprintf("%s/%d", qtree.Volume.UUID, qtree.Id)
+	qtree.VolumeUUID = qtree.Volume.UUID
+	qtree.SVMUUID = qtree.SVM.UUID
+
+	qtree.SVM = UUIDRef{}
+	qtree.Volume = UUIDRef{}
 }
 
 // This is the JSON representation of a Qtree for REST Create / Update
@@ -60,7 +88,7 @@ func (c *Client) CreateQtree(qtree *Qtree) (*Qtree, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("https://%s/api/storage/qtrees?return_records=true", c.HostURL), bytes.NewBuffer(req_qtreeJSON))
+	req, err := http.NewRequest("POST", c.restURL("/storage/qtrees", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_qtreeJSON))
 
 	if err != nil {
 		return nil, err
@@ -84,7 +112,7 @@ func (c *Client) CreateQtree(qtree *Qtree) (*Qtree, error) {
 func (c *Client) GetQtree(uuid string, qtreeName string) (*Qtree, error) {
 	// s := strings.Split(uuid, "/")
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/api/storage/qtrees/%s", c.HostURL, uuid), nil)
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/storage/qtrees/%s", uuid), url.Values{"fields": {c.qtreeFields()}}), nil)
 
 	if err != nil {
 		return nil, err
@@ -103,64 +131,49 @@ func (c *Client) GetQtree(uuid string, qtreeName string) (*Qtree, error) {
 		return nil, err
 	}
 
-	qtree.VolumeUUID = qtree.Volume.UUID
-	qtree.SVMUUID = qtree.SVM.UUID
-
-	qtree.SVM = UUIDRef{}
-	qtree.Volume = UUIDRef{}
+	qtree.fromRest()
 
-	qtree.UUID = uuid
 	return &qtree, nil
 }
+
+// GetQtreeInVolume looks up a qtree by name, the record returned by the
+// collection holds every field so no second GET is needed
 func (c *Client) GetQtreeInVolume(volume_uuid string, name string) (*Qtree, error) {
 
-	req_id, err := http.NewRequest("GET", fmt.Sprintf("https://%s/api/storage/qtrees?volume.uuid=%s&name=%s", c.HostURL, volume_uuid, name), nil)
+	qtree, err := GetCollectionRecord[Qtree](c, "/storage/qtrees", &CollectionQuery{
+		Query:  url.Values{"volume.uuid": []string{volume_uuid}, "name": []string{QueryLiteral(name)}},
+		Fields: []string{c.qtreeFields()},
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	body_id, err := c.doRequest(req_id)
-	if err != nil {
-		return nil, err
-	}
+	qtree.fromRest()
 
-	qtree_result := QtreeJSONRecordsResponse{}
-	err = json.Unmarshal(body_id, &qtree_result)
-	if err != nil {
-		return nil, err
-	}
-	qtreeUUID := fmt.Sprintf("%s/%d", volume_uuid, qtree_result.Records[0].ID)
+	return qtree, nil
+}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/api/storage/qtrees/%s", c.HostURL, qtreeUUID), nil)
+// GetQtrees returns the qtrees matching an ONTAP query, i.e.
+// volume.uuid=<uuid>
+func (c *Client) GetQtrees(query url.Values) ([]Qtree, error) {
 
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
+	qtrees, err := GetCollection[Qtree](c, "/storage/qtrees", &CollectionQuery{
+		Query:  query,
+		Fields: []string{c.qtreeFields()},
+	})
 
-	qtree := Qtree{}
-	err = json.Unmarshal(body, &qtree)
 	if err != nil {
 		return nil, err
 	}
-	qtree.UUID = qtreeUUID
-	qtree.VolumeUUID = qtree.Volume.UUID
-	qtree.SVMUUID = qtree.SVM.UUID
 
-	qtree.SVM = UUIDRef{}
-	qtree.Volume = UUIDRef{}
-
-	if err != nil {
-		return nil, err
+	for i := range qtrees {
+		qtrees[i].fromRest()
 	}
 
-	return &qtree, nil
+	return qtrees, nil
 }
+
 func (c *Client) UpdateQtree(qtree *Qtree) (*Qtree, error) {
 
 	req_body, err := qtree.RestMarshall()
@@ -169,7 +182,7 @@ func (c *Client) UpdateQtree(qtree *Qtree) (*Qtree, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("https://%s/api/storage/qtrees/%s", c.HostURL, qtree.UUID), bytes.NewBuffer(req_body))
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/storage/qtrees/%s", qtree.UUID), nil), bytes.NewBuffer(req_body))
 
 	if err != nil {
 		return nil, err
@@ -192,7 +205,7 @@ func (c *Client) UpdateQtree(qtree *Qtree) (*Qtree, error) {
 
 func (c *Client) DeleteQtree(qtree *Qtree) error {
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("https://%s/api/storage/qtrees/%s", c.HostURL, qtree.UUID), nil)
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/storage/qtrees/%s", qtree.UUID), nil), nil)
 
 	if err != nil {
 		return err
@@ -0,0 +1,103 @@
+package ontap_test
+
+import (
+	"strings"
+	"testing"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestCreateQtree(t *testing.T) {
+	client := ontaptest.NewClient(t, "qtree_create")
+
+	qtree, err := client.CreateQtree(&ontap.Qtree{
+		SVMUUID:        "0b9a2b5e-5ff8-11ed-9c6a-005056b0a2b1",
+		VolumeUUID:     "5e3f7c4a-5ff9-11ed-9c6a-005056b0a2b1",
+		Name:           "home",
+		SecurityStyle:  "unix",
+		UnixPermission: 755,
+	})
+
+	if err != nil {
+		t.Fatalf("unable to create qtree, got error: %s", err)
+	}
+
+	if qtree.UUID != "5e3f7c4a-5ff9-11ed-9c6a-005056b0a2b1/3" {
+		t.Errorf("expected uuid 5e3f7c4a-5ff9-11ed-9c6a-005056b0a2b1/3, got %s", qtree.UUID)
+	}
+}
+
+func TestGetQtreeInVolumeMatchesLiteralName(t *testing.T) {
+	client := ontaptest.NewClient(t, "qtree_in_volume")
+
+	qtree, err := client.GetQtreeInVolume("5e3f7c4a-5ff9-11ed-9c6a-005056b0a2b1", "home*")
+
+	if err != nil {
+		t.Fatalf("unable to get qtree, got error: %s", err)
+	}
+
+	if qtree.UUID != "5e3f7c4a-5ff9-11ed-9c6a-005056b0a2b1/3" {
+		t.Errorf("expected uuid 5e3f7c4a-5ff9-11ed-9c6a-005056b0a2b1/3, got %s", qtree.UUID)
+	}
+
+	if qtree.SVMUUID != "0b9a2b5e-5ff8-11ed-9c6a-005056b0a2b1" {
+		t.Errorf("expected svm uuid 0b9a2b5e-5ff8-11ed-9c6a-005056b0a2b1, got %s", qtree.SVMUUID)
+	}
+
+	if qtree.QOSPolicy == nil || qtree.QOSPolicy.Name != "gold" {
+		t.Errorf("expected qos policy gold, got %v", qtree.QOSPolicy)
+	}
+}
+
+func TestGetQtreeInVolumeBefore98(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	simulator.SetVersion(9, 7, 0)
+	svm_uuid := simulator.AddSVM("svm_qtree_97")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_qtree_97")
+	simulator.AddQtree(volume_uuid, "home", "unix")
+
+	client := simulator.Client(t)
+
+	// qos_policy is requested when the version isn't detected, and rejected
+	// as an unknown field before ONTAP 9.8
+	_, err := client.GetQtreeInVolume(volume_uuid, "home")
+
+	if err == nil || !strings.Contains(err.Error(), "qos_policy") {
+		t.Fatalf("expected qos_policy to be rejected, got: %v", err)
+	}
+
+	_, err = client.DetectVersion()
+
+	if err != nil {
+		t.Fatalf("unable to detect version, got error: %s", err)
+	}
+
+	qtree, err := client.GetQtreeInVolume(volume_uuid, "home")
+
+	if err != nil {
+		t.Fatalf("unable to get qtree, got error: %s", err)
+	}
+
+	if qtree.SecurityStyle != "unix" || qtree.QOSPolicy != nil {
+		t.Errorf("expected a unix qtree without qos policy, got %+v", qtree)
+	}
+
+	created, err := client.CreateQtree(&ontap.Qtree{
+		SVMUUID:        svm_uuid,
+		VolumeUUID:     volume_uuid,
+		Name:           "projects",
+		SecurityStyle:  "ntfs",
+		UnixPermission: 700,
+	})
+
+	if err != nil {
+		t.Fatalf("unable to create qtree, got error: %s", err)
+	}
+
+	if created.Path != "/vol_qtree_97/projects" {
+		t.Errorf("expected path /vol_qtree_97/projects, got %s", created.Path)
+	}
+}
@@ -0,0 +1,384 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type S3Service struct {
+	SVM            *UUIDRef `json:"svm,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Enabled        *bool    `json:"enabled,omitempty"`
+	Comment        *string  `json:"comment,omitempty"`
+	Certificate    *UUIDRef `json:"certificate,omitempty"`
+	IsHTTPEnabled  *bool    `json:"is_http_enabled,omitempty"`
+	IsHTTPSEnabled *bool    `json:"is_https_enabled,omitempty"`
+	Port           *int64   `json:"port,omitempty"`
+	SecurePort     *int64   `json:"secure_port,omitempty"`
+}
+
+type S3Bucket struct {
+	UUID string `json:"uuid,omitempty"`
+
+	SVM             *UUIDRef        `json:"svm,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	Size            int64           `json:"size,omitempty"`
+	LogicalUsedSize int64           `json:"logical_used_size,omitempty"`
+	Comment         *string         `json:"comment,omitempty"`
+	VersioningState string          `json:"versioning_state,omitempty"`
+	Policy          *S3BucketPolicy `json:"policy,omitempty"`
+}
+
+// s3BucketFields returns the fields requested for buckets, the policy isn't
+// part of the default representation and is only known from ONTAP 9.8
+func (c *Client) s3BucketFields() string {
+	if c.Version != nil && !c.Version.AtLeast(9, 8, 0) {
+		return "*"
+	}
+
+	return "*,policy"
+}
+
+type S3BucketPolicy struct {
+	Statements []S3BucketPolicyStatement `json:"statements"`
+}
+
+type S3BucketPolicyStatement struct {
+	Sid        string   `json:"sid,omitempty"`
+	Effect     string   `json:"effect,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+	Principals []string `json:"principals,omitempty"`
+	Resources  []string `json:"resources,omitempty"`
+}
+
+// S3User is an S3 user, ONTAP only returns the secret key when the user is
+// created
+type S3User struct {
+	SVM       *UUIDRef `json:"svm,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Comment   *string  `json:"comment,omitempty"`
+	AccessKey string   `json:"access_key,omitempty"`
+	SecretKey string   `json:"secret_key,omitempty"`
+}
+
+func (c *Client) CreateS3Service(service *S3Service) (*S3Service, error) {
+
+	req_body, err := json.Marshal(service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/s3/services", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	svm_uuid, err := c.GetSVMUUID(service.SVM.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetS3Service(svm_uuid)
+}
+
+func (c *Client) GetS3Service(svm_uuid string) (*S3Service, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/s3/services/%s", svm_uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	service := S3Service{}
+
+	err = json.Unmarshal(body, &service)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+func (c *Client) UpdateS3Service(service *S3Service) (*S3Service, error) {
+
+	service_copy := *service
+	service_copy.SVM = nil
+
+	req_body, err := json.Marshal(service_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/s3/services/%s", service.SVM.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetS3Service(service.SVM.UUID)
+}
+
+// DeleteS3Service deletes the S3 server of an SVM, buckets and users are
+// kept and must be deleted beforehand
+func (c *Client) DeleteS3Service(svm_uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/s3/services/%s", svm_uuid), url.Values{"delete_all": {"false"}}), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) CreateS3Bucket(bucket *S3Bucket) (*S3Bucket, error) {
+
+	bucket_copy := *bucket
+	bucket_copy.UUID = ""
+
+	req_body, err := json.Marshal(bucket_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/protocols/s3/buckets", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Bucket creation is a job, the bucket is looked up by name once it is
+	// complete
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetS3BucketByName(bucket.SVM.Name, bucket.Name)
+}
+
+func (c *Client) GetS3Bucket(svm_uuid string, uuid string) (*S3Bucket, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/s3/buckets/%s/%s", svm_uuid, uuid), url.Values{"fields": {c.s3BucketFields()}}), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := S3Bucket{}
+
+	err = json.Unmarshal(body, &bucket)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &bucket, nil
+}
+
+func (c *Client) GetS3BucketByName(svm_name string, name string) (*S3Bucket, error) {
+
+	return GetCollectionRecord[S3Bucket](c, "/protocols/s3/buckets", &CollectionQuery{
+		Query:  url.Values{"svm.name": []string{QueryLiteral(svm_name)}, "name": []string{QueryLiteral(name)}},
+		Fields: []string{c.s3BucketFields()},
+	})
+}
+
+func (c *Client) UpdateS3Bucket(bucket *S3Bucket) (*S3Bucket, error) {
+
+	bucket_copy := *bucket
+	bucket_copy.UUID = ""
+	bucket_copy.SVM = nil
+	bucket_copy.Name = ""
+	bucket_copy.LogicalUsedSize = 0
+
+	req_body, err := json.Marshal(bucket_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/s3/buckets/%s/%s", bucket.SVM.UUID, bucket.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetS3Bucket(bucket.SVM.UUID, bucket.UUID)
+}
+
+func (c *Client) DeleteS3Bucket(svm_uuid string, uuid string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/s3/buckets/%s/%s", svm_uuid, uuid), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateS3User creates an S3 user, the returned user holds the generated
+// access and secret keys
+func (c *Client) CreateS3User(svm_uuid string, user *S3User) (*S3User, error) {
+
+	user_copy := *user
+	user_copy.SVM = nil
+
+	req_body, err := json.Marshal(user_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL(fmt.Sprintf("/protocols/s3/services/%s/users", svm_uuid), url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user_result := CollectionResponse[S3User]{}
+	err = json.Unmarshal(body, &user_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(user_result.Records) == 0 {
+		return nil, fmt.Errorf("s3 user %s was not returned after creation", user.Name)
+	}
+
+	created_user, err := c.GetS3User(svm_uuid, user.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	created_user.AccessKey = user_result.Records[0].AccessKey
+	created_user.SecretKey = user_result.Records[0].SecretKey
+
+	return created_user, nil
+}
+
+func (c *Client) GetS3User(svm_uuid string, name string) (*S3User, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/protocols/s3/services/%s/users/%s", svm_uuid, url.PathEscape(name)), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user := S3User{}
+
+	err = json.Unmarshal(body, &user)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (c *Client) UpdateS3User(svm_uuid string, user *S3User) (*S3User, error) {
+
+	req_body, err := json.Marshal(S3User{Comment: user.Comment})
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/protocols/s3/services/%s/users/%s", svm_uuid, url.PathEscape(user.Name)), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetS3User(svm_uuid, user.Name)
+}
+
+func (c *Client) DeleteS3User(svm_uuid string, name string) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/protocols/s3/services/%s/users/%s", svm_uuid, url.PathEscape(name)), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type Schedule struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name     string        `json:"name,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	Cron     *ScheduleCron `json:"cron,omitempty"`
+	Interval string        `json:"interval,omitempty"`
+}
+
+type ScheduleCron struct {
+	Minutes  []int64 `json:"minutes,omitempty"`
+	Hours    []int64 `json:"hours,omitempty"`
+	Days     []int64 `json:"days,omitempty"`
+	Weekdays []int64 `json:"weekdays,omitempty"`
+	Months   []int64 `json:"months,omitempty"`
+}
+
+func (c *Client) CreateSchedule(schedule *Schedule) (*Schedule, error) {
+
+	req_body, err := json.Marshal(schedule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/cluster/schedules", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedule_result := CollectionResponse[Schedule]{}
+	err = json.Unmarshal(body, &schedule_result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedule_result.Records) == 0 {
+		return nil, fmt.Errorf("schedule %s was not returned after creation", schedule.Name)
+	}
+
+	return c.GetSchedule(schedule_result.Records[0].UUID)
+}
+
+func (c *Client) GetSchedule(uuid string) (*Schedule, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/cluster/schedules/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := Schedule{}
+
+	err = json.Unmarshal(body, &schedule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// schedulePatch is the body of a schedule PATCH, name and type can't be
+// modified and ONTAP rejects them
+type schedulePatch struct {
+	Cron     *scheduleCronPatch `json:"cron,omitempty"`
+	Interval string             `json:"interval,omitempty"`
+}
+
+// scheduleCronPatch sends every cron field, an empty array matching every
+// value, otherwise ONTAP keeps the previous value of omitted fields
+type scheduleCronPatch struct {
+	Minutes  []int64 `json:"minutes"`
+	Hours    []int64 `json:"hours"`
+	Days     []int64 `json:"days"`
+	Weekdays []int64 `json:"weekdays"`
+	Months   []int64 `json:"months"`
+}
+
+func (c *Client) UpdateSchedule(schedule *Schedule) (*Schedule, error) {
+
+	schedule_patch := schedulePatch{
+		Interval: schedule.Interval,
+	}
+	if schedule.Cron != nil {
+		schedule_patch.Cron = &scheduleCronPatch{
+			Minutes:  nonNilInt64s(schedule.Cron.Minutes),
+			Hours:    nonNilInt64s(schedule.Cron.Hours),
+			Days:     nonNilInt64s(schedule.Cron.Days),
+			Weekdays: nonNilInt64s(schedule.Cron.Weekdays),
+			Months:   nonNilInt64s(schedule.Cron.Months),
+		}
+	}
+
+	req_body, err := json.Marshal(schedule_patch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/cluster/schedules/%s", schedule.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSchedule(schedule.UUID)
+}
+
+func (c *Client) DeleteSchedule(schedule *Schedule) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/cluster/schedules/%s", schedule.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// nonNilInt64s returns values, or an empty slice marshalled as [] when nil
+func nonNilInt64s(values []int64) []int64 {
+	if values == nil {
+		return []int64{}
+	}
+	return values
+}
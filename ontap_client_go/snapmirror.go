@@ -0,0 +1,232 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type SnapmirrorRelationship struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Source           SnapmirrorEndpoint  `json:"source"`
+	Destination      SnapmirrorEndpoint  `json:"destination"`
+	Policy           *UUIDRef            `json:"policy,omitempty"`
+	TransferSchedule *UUIDRef            `json:"transfer_schedule,omitempty"`
+	State            string              `json:"state,omitempty"`
+	Healthy          *bool               `json:"healthy,omitempty"`
+	UnhealthyReason  []SnapmirrorError   `json:"unhealthy_reason,omitempty"`
+	LagTime          string              `json:"lag_time,omitempty"`
+	Transfer         *SnapmirrorTransfer `json:"transfer,omitempty"`
+}
+
+type SnapmirrorEndpoint struct {
+	Path    string   `json:"path,omitempty"`
+	SVM     *UUIDRef `json:"svm,omitempty"`
+	Cluster *UUIDRef `json:"cluster,omitempty"`
+}
+
+type SnapmirrorTransfer struct {
+	UUID  string `json:"uuid,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+type SnapmirrorError struct {
+	Message string `json:"message,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// snapmirrorRelationshipPatch is the body of a PATCH modifying relationship
+// properties. transfer_schedule is always sent so that a null value removes
+// the schedule.
+type snapmirrorRelationshipPatch struct {
+	Policy           *UUIDRef `json:"policy,omitempty"`
+	TransferSchedule *UUIDRef `json:"transfer_schedule"`
+}
+
+// Interval between two checks of a relationship while waiting for its
+// initialization transfer to complete
+var snapmirrorPollInterval = 5 * time.Second
+
+// DefaultSnapmirrorInitializationTimeout is the time waited for a baseline
+// transfer when no timeout is configured
+const DefaultSnapmirrorInitializationTimeout = time.Hour
+
+func (c *Client) CreateSnapmirrorRelationship(relationship *SnapmirrorRelationship) (*SnapmirrorRelationship, error) {
+
+	relationship_copy := *relationship
+	relationship_copy.UUID = ""
+
+	req_body, err := json.Marshal(relationship_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/snapmirror/relationships", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapmirrorRelationshipByDestination(relationship.Destination.Path)
+}
+
+func (c *Client) GetSnapmirrorRelationship(uuid string) (*SnapmirrorRelationship, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/snapmirror/relationships/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	relationship := SnapmirrorRelationship{}
+
+	err = json.Unmarshal(body, &relationship)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &relationship, nil
+}
+
+func (c *Client) GetSnapmirrorRelationshipByDestination(path string) (*SnapmirrorRelationship, error) {
+
+	return GetCollectionRecord[SnapmirrorRelationship](c, "/snapmirror/relationships", &CollectionQuery{
+		Query:  url.Values{"destination.path": []string{QueryLiteral(path)}},
+		Fields: []string{"*"},
+	})
+}
+
+// UpdateSnapmirrorRelationship modifies the policy and transfer schedule of a
+// relationship. State changes are made with SetSnapmirrorRelationshipState.
+func (c *Client) UpdateSnapmirrorRelationship(relationship *SnapmirrorRelationship) (*SnapmirrorRelationship, error) {
+
+	patch := snapmirrorRelationshipPatch{
+		Policy:           relationship.Policy,
+		TransferSchedule: relationship.TransferSchedule,
+	}
+
+	req_body, err := json.Marshal(patch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/snapmirror/relationships/%s", relationship.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapmirrorRelationship(relationship.UUID)
+}
+
+// SetSnapmirrorRelationshipState changes the state of a relationship, which
+// initializes, resyncs or resumes it (snapmirrored), breaks it (broken_off)
+// or quiesces it (paused)
+func (c *Client) SetSnapmirrorRelationshipState(uuid string, state string) (*SnapmirrorRelationship, error) {
+
+	req_body, err := json.Marshal(map[string]string{"state": state})
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/snapmirror/relationships/%s", uuid), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapmirrorRelationship(uuid)
+}
+
+// WaitSnapmirrorRelationshipInitialized polls a relationship until its
+// baseline transfer is complete, or fails after timeout. The transfer isn't a
+// cluster job, it is tracked by the relationship itself, so the job poller
+// can't be used.
+func (c *Client) WaitSnapmirrorRelationshipInitialized(uuid string, timeout time.Duration) (*SnapmirrorRelationship, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		relationship, err := c.GetSnapmirrorRelationship(uuid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if relationship.State != "uninitialized" && relationship.Transfer == nil {
+			return relationship, nil
+		}
+
+		if relationship.Transfer != nil {
+			switch relationship.Transfer.State {
+			case "failed", "aborted", "hard_aborted":
+				return nil, fmt.Errorf("snapmirror initialization transfer %s %s", relationship.Transfer.UUID, relationship.Transfer.State)
+			}
+		} else if relationship.Healthy != nil && !*relationship.Healthy {
+			reasons := []string{}
+			for _, r := range relationship.UnhealthyReason {
+				reasons = append(reasons, r.Message)
+			}
+			return nil, fmt.Errorf("snapmirror relationship is unhealthy: %s", strings.Join(reasons, ", "))
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("snapmirror relationship is still %s after %s", relationship.State, timeout)
+		}
+
+		err = c.sleep(snapmirrorPollInterval)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) DeleteSnapmirrorRelationship(relationship *SnapmirrorRelationship) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/snapmirror/relationships/%s", relationship.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type SnapmirrorPolicy struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Name                      string                 `json:"name,omitempty"`
+	SVM                       *UUIDRef               `json:"svm,omitempty"`
+	Type                      string                 `json:"type,omitempty"`
+	SyncType                  string                 `json:"sync_type,omitempty"`
+	Comment                   *string                `json:"comment,omitempty"`
+	Retention                 []SnapmirrorPolicyRule `json:"retention,omitempty"`
+	TransferSchedule          *UUIDRef               `json:"transfer_schedule,omitempty"`
+	NetworkCompressionEnabled *bool                  `json:"network_compression_enabled,omitempty"`
+	IdentityPreservation      string                 `json:"identity_preservation,omitempty"`
+}
+
+type SnapmirrorPolicyRule struct {
+	Label            string   `json:"label"`
+	Count            int64    `json:"count"`
+	CreationSchedule *UUIDRef `json:"creation_schedule,omitempty"`
+	Prefix           string   `json:"prefix,omitempty"`
+}
+
+// snapmirrorPolicyPatch is the body of a PATCH modifying a policy. Transfer
+// schedule is always sent so it can be removed, retention rules are sent when
+// set, an empty list removing them.
+type snapmirrorPolicyPatch struct {
+	Comment                   *string                 `json:"comment,omitempty"`
+	Retention                 *[]SnapmirrorPolicyRule `json:"retention,omitempty"`
+	TransferSchedule          *UUIDRef                `json:"transfer_schedule"`
+	NetworkCompressionEnabled *bool                   `json:"network_compression_enabled,omitempty"`
+	IdentityPreservation      string                  `json:"identity_preservation,omitempty"`
+}
+
+func (c *Client) CreateSnapmirrorPolicy(policy *SnapmirrorPolicy) (*SnapmirrorPolicy, error) {
+
+	policy_copy := *policy
+	policy_copy.UUID = ""
+
+	req_body, err := json.Marshal(policy_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL("/snapmirror/policies", nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	svm_name := ""
+	if policy.SVM != nil {
+		svm_name = policy.SVM.Name
+	}
+
+	return c.GetSnapmirrorPolicyByName(svm_name, policy.Name)
+}
+
+func (c *Client) GetSnapmirrorPolicy(uuid string) (*SnapmirrorPolicy, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/snapmirror/policies/%s", uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	policy := SnapmirrorPolicy{}
+
+	err = json.Unmarshal(body, &policy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// GetSnapmirrorPolicyByName looks up a policy by name, svm_name being empty
+// for cluster scoped policies
+func (c *Client) GetSnapmirrorPolicyByName(svm_name string, name string) (*SnapmirrorPolicy, error) {
+
+	query := url.Values{"name": []string{QueryLiteral(name)}, "scope": []string{"cluster"}}
+	if svm_name != "" {
+		query = url.Values{"name": []string{QueryLiteral(name)}, "svm.name": []string{QueryLiteral(svm_name)}}
+	}
+
+	return GetCollectionRecord[SnapmirrorPolicy](c, "/snapmirror/policies", &CollectionQuery{
+		Query:  query,
+		Fields: []string{"*"},
+	})
+}
+
+func (c *Client) UpdateSnapmirrorPolicy(policy *SnapmirrorPolicy) (*SnapmirrorPolicy, error) {
+
+	patch := snapmirrorPolicyPatch{
+		Comment:                   policy.Comment,
+		TransferSchedule:          policy.TransferSchedule,
+		NetworkCompressionEnabled: policy.NetworkCompressionEnabled,
+		IdentityPreservation:      policy.IdentityPreservation,
+	}
+
+	if policy.Retention != nil {
+		patch.Retention = &policy.Retention
+	}
+
+	req_body, err := json.Marshal(patch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/snapmirror/policies/%s", policy.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapmirrorPolicy(policy.UUID)
+}
+
+func (c *Client) DeleteSnapmirrorPolicy(policy *SnapmirrorPolicy) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/snapmirror/policies/%s", policy.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package ontap_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+)
+
+// newUninitializedClient returns a client for a relationship that stays
+// uninitialized without any transfer
+func newUninitializedClient(t *testing.T) *ontap.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"uuid":"4ea7a442-86d1-11e0-ae1c-123478563412","state":"uninitialized","healthy":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	host := server.URL
+	username := "admin"
+	password := "password"
+
+	client, err := ontap.NewClient(&host, &username, &password, false)
+
+	if err != nil {
+		t.Fatalf("unable to create client, got error: %s", err)
+	}
+
+	return client
+}
+
+func TestWaitSnapmirrorRelationshipInitializedTimeout(t *testing.T) {
+	client := newUninitializedClient(t)
+
+	_, err := client.WaitSnapmirrorRelationshipInitialized("4ea7a442-86d1-11e0-ae1c-123478563412", time.Nanosecond)
+
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+}
+
+func TestWaitSnapmirrorRelationshipInitializedCanceled(t *testing.T) {
+	client := newUninitializedClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, err := client.WithContext(ctx).WaitSnapmirrorRelationshipInitialized("4ea7a442-86d1-11e0-ae1c-123478563412", time.Hour)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the wait to be canceled, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package ontap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type Snapshot struct {
+	UUID string `json:"uuid,omitempty"`
+
+	Volume     UUIDRef `json:"volume,omitempty"`
+	VolumeUUID string  `json:"-"`
+
+	Name            string  `json:"name,omitempty"`
+	Comment         *string `json:"comment,omitempty"`
+	CreateTime      string  `json:"create_time,omitempty"`
+	ExpiryTime      string  `json:"expiry_time,omitempty"`
+	SnapmirrorLabel *string `json:"snapmirror_label,omitempty"`
+	State           string  `json:"state,omitempty"`
+	Size            int64   `json:"size,omitempty"`
+}
+
+// Fields requested when listing snapshots, as collection GETs only return
+// uuid and name by default
+const snapshotFields = "uuid,name,volume,comment,create_time,expiry_time,snapmirror_label,state,size"
+
+func (c *Client) CreateSnapshot(snapshot *Snapshot) (*Snapshot, error) {
+
+	snapshot_copy := *snapshot
+	snapshot_copy.Volume = UUIDRef{}
+	snapshot_copy.UUID = ""
+
+	req_body, err := json.Marshal(snapshot_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.restURL(fmt.Sprintf("/storage/volumes/%s/snapshots", snapshot.VolumeUUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapshotInVolume(snapshot.VolumeUUID, snapshot.Name)
+}
+
+func (c *Client) GetSnapshot(volume_uuid string, uuid string) (*Snapshot, error) {
+
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/storage/volumes/%s/snapshots/%s", volume_uuid, uuid), nil), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := Snapshot{}
+
+	err = json.Unmarshal(body, &snapshot)
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.VolumeUUID = volume_uuid
+
+	return &snapshot, nil
+}
+
+func (c *Client) GetSnapshotInVolume(volume_uuid string, name string) (*Snapshot, error) {
+
+	snapshot, err := GetCollectionRecord[Snapshot](c, fmt.Sprintf("/storage/volumes/%s/snapshots", volume_uuid), &CollectionQuery{
+		Query:  url.Values{"name": []string{QueryLiteral(name)}},
+		Fields: []string{snapshotFields},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.VolumeUUID = volume_uuid
+
+	return snapshot, nil
+}
+
+// GetSnapshots returns snapshots of a volume matching query, which can use
+// ONTAP query syntax, i.e. name=daily.* or create_time=>2022-01-01T00:00:00Z
+func (c *Client) GetSnapshots(volume_uuid string, query url.Values) ([]Snapshot, error) {
+
+	snapshots, err := GetCollection[Snapshot](c, fmt.Sprintf("/storage/volumes/%s/snapshots", volume_uuid), &CollectionQuery{
+		Query:  query,
+		Fields: []string{snapshotFields},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range snapshots {
+		snapshots[i].VolumeUUID = volume_uuid
+	}
+
+	return snapshots, nil
+}
+
+func (c *Client) UpdateSnapshot(snapshot *Snapshot) (*Snapshot, error) {
+
+	snapshot_copy := *snapshot
+	snapshot_copy.Volume = UUIDRef{}
+	snapshot_copy.UUID = ""
+	snapshot_copy.CreateTime = ""
+	snapshot_copy.State = ""
+	snapshot_copy.Size = 0
+
+	req_body, err := json.Marshal(snapshot_copy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/storage/volumes/%s/snapshots/%s", snapshot.VolumeUUID, snapshot.UUID), nil), bytes.NewBuffer(req_body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetSnapshot(snapshot.VolumeUUID, snapshot.UUID)
+}
+
+func (c *Client) DeleteSnapshot(snapshot *Snapshot) error {
+
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/storage/volumes/%s/snapshots/%s", snapshot.VolumeUUID, snapshot.UUID), nil), nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
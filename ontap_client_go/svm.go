@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 )
 
 type SVM struct {
@@ -21,7 +22,7 @@ type SVM struct {
 	FCP                 *FCP            `json:"fcp,omitempty"`
 	IPInterfaces        []IPInterface   `json:"ip_interfaces,omitempty"`
 	IPSpace             UUIDRef         `json:"ipspace,omitempty"`
-	ISCSI               ISCSI           `json:"iscsi,omitempty"`
+	ISCSI               *ISCSI          `json:"iscsi,omitempty"`
 	Language            string          `json:"language,omitempty"`
 	LDAP                *LDAP           `json:"ldap,omitempty"`
 	NFS                 *NFS            `json:"nfs,omitempty"`
@@ -132,15 +133,6 @@ type SnapshotPolicy struct {
 	UUID string `json:"uuid,omitempty"`
 }
 
-type SVMSearchResult struct {
-	NumRecords int64    `json:"num_records,omitempty"`
-	Records    []Record `json:"records,omitempty"`
-}
-type Record struct {
-	UUID string `json:"uuid,omitempty"`
-	Name string `json:"name,omitempty"`
-}
-
 func (c *Client) CreateSVM(svm *SVM) (*SVM, error) {
 
 	req_SVMJSON, err := json.Marshal(svm)
@@ -149,7 +141,7 @@ func (c *Client) CreateSVM(svm *SVM) (*SVM, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("https://%s/api/svm/svms?return_records=true", c.HostURL), bytes.NewBuffer(req_SVMJSON))
+	req, err := http.NewRequest("POST", c.restURL("/svm/svms", url.Values{"return_records": {"true"}}), bytes.NewBuffer(req_SVMJSON))
 
 	if err != nil {
 		return nil, err
@@ -170,27 +162,18 @@ func (c *Client) CreateSVM(svm *SVM) (*SVM, error) {
 	return new_svm, nil
 }
 
+// GetSVM returns an SVM by UUID, or by name when name is set, in which case
+// the collection record is returned with all its fields in a single call
 func (c *Client) GetSVM(uuid *string, name *string) (*SVM, error) {
 
 	if name != nil {
-		req_id, err := http.NewRequest("GET", fmt.Sprintf("https://%s/api/svm/svms?name=%s", c.HostURL, *name), nil)
-
-		if err != nil {
-			return nil, err
-		}
-
-		body, err := c.doRequest(req_id)
-
-		if err != nil {
-			return nil, err
-		}
-
-		svm_result := SVMSearchResult{}
-		json.Unmarshal(body, &svm_result)
-		uuid = &svm_result.Records[0].UUID
+		return GetCollectionRecord[SVM](c, "/svm/svms", &CollectionQuery{
+			Query:  url.Values{"name": []string{QueryLiteral(*name)}},
+			Fields: []string{"*"},
+		})
 	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/api/svm/svms/%s", c.HostURL, *uuid), nil)
+	req, err := http.NewRequest("GET", c.restURL(fmt.Sprintf("/svm/svms/%s", *uuid), nil), nil)
 
 	if err != nil {
 		return nil, err
@@ -213,19 +196,51 @@ func (c *Client) GetSVM(uuid *string, name *string) (*SVM, error) {
 	return &svm, nil
 }
 
+// GetSVMUUID returns the UUID of an SVM from its name, without fetching its
+// other fields
+func (c *Client) GetSVMUUID(name string) (string, error) {
+
+	svm, err := GetCollectionRecord[SVM](c, "/svm/svms", &CollectionQuery{
+		Query:  url.Values{"name": []string{QueryLiteral(name)}},
+		Fields: []string{"uuid"},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if svm.UUID == nil {
+		return "", fmt.Errorf("uuid of svm %s was not returned", name)
+	}
+
+	return *svm.UUID, nil
+}
+
+// GetSVMs returns the SVMs matching an ONTAP query, i.e. state=running, with
+// the same fields as GetSVM
+func (c *Client) GetSVMs(query url.Values) ([]SVM, error) {
+	return GetCollection[SVM](c, "/svm/svms", &CollectionQuery{
+		Query:  query,
+		Fields: []string{"*"},
+	})
+}
+
 func (c *Client) UpdateSVM(svm *SVM) (*SVM, error) {
 
 	uuid := svm.UUID
 	svm.UUID = nil
 
+	// Protocol services can't be modified on the SVM, they are managed with
+	// the iSCSI and FC service endpoints
 	svm.FCP = nil
+	svm.ISCSI = nil
 	req_body, err := json.Marshal(svm)
 
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("https://%s/api/svm/svms/%s", c.HostURL, *uuid), bytes.NewBuffer(req_body))
+	req, err := http.NewRequest("PATCH", c.restURL(fmt.Sprintf("/svm/svms/%s", *uuid), nil), bytes.NewBuffer(req_body))
 
 	if err != nil {
 		return nil, err
@@ -248,7 +263,7 @@ func (c *Client) UpdateSVM(svm *SVM) (*SVM, error) {
 
 func (c *Client) DeleteSVM(svm *SVM) error {
 
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("https://%s/api/svm/svms/%s", c.HostURL, *svm.UUID), nil)
+	req, err := http.NewRequest("DELETE", c.restURL(fmt.Sprintf("/svm/svms/%s", *svm.UUID), nil), nil)
 
 	if err != nil {
 		return err
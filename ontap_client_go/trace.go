@@ -0,0 +1,169 @@
+package ontap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedValue replaces secrets in logged headers and bodies
+const redactedValue = "***"
+
+// sensitiveFields are the JSON fields redacted from logged bodies, at any
+// depth, i.e. iSCSI CHAP passwords in authentication.inbound.password
+var sensitiveFields = map[string]bool{
+	"password":   true,
+	"passphrase": true,
+	"access_key": true,
+	"secret_key": true,
+}
+
+// sensitiveHeaders are the headers redacted from logs
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// WithContext returns a copy of c sharing its connections and limits, which
+// logs requests to the tflog logger of ctx and cancels them with ctx. Fields
+// set on the copy, like Version, aren't set on c.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	client := *c
+	client.ctx = ctx
+
+	return &client
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// traceRequest logs req before it is sent, its body at trace level
+func (c *Client) traceRequest(req *http.Request) {
+	ctx := c.context()
+
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": redactHeaders(req.Header),
+	}
+
+	tflog.Debug(ctx, "sending ONTAP request", fields)
+
+	if req.GetBody == nil {
+		return
+	}
+
+	body_reader, err := req.GetBody()
+
+	if err != nil {
+		return
+	}
+
+	body, err := ioutil.ReadAll(body_reader)
+
+	if err != nil || len(body) == 0 {
+		return
+	}
+
+	fields["body"] = RedactBody(body)
+
+	tflog.Trace(ctx, "ONTAP request body", fields)
+}
+
+// traceResponse logs the response to req received after duration, its body at
+// trace level
+func (c *Client) traceResponse(req *http.Request, res *http.Response, body []byte, duration time.Duration) {
+	ctx := c.context()
+
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"status":      res.StatusCode,
+		"duration_ms": duration.Milliseconds(),
+		"headers":     redactHeaders(res.Header),
+	}
+
+	tflog.Debug(ctx, "received ONTAP response", fields)
+
+	if len(body) == 0 {
+		return
+	}
+
+	fields["body"] = RedactBody(body)
+
+	tflog.Trace(ctx, "ONTAP response body", fields)
+}
+
+// traceJob logs the state of the ONTAP job at href
+func (c *Client) traceJob(href string, status *JobStatus) {
+	tflog.Debug(c.context(), "ONTAP job status", map[string]interface{}{
+		"job_uuid": status.UUID,
+		"href":     href,
+		"state":    status.State,
+		"message":  status.Message,
+	})
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := map[string]string{}
+
+	for name, values := range headers {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = redactedValue
+			continue
+		}
+		redacted[name] = fmt.Sprint(values)
+	}
+
+	return redacted
+}
+
+// RedactBody returns body with the values of sensitive fields replaced, to be
+// logged or stored. A body that isn't JSON is replaced by its size as it can't
+// be redacted.
+func RedactBody(body []byte) string {
+	var value interface{}
+
+	err := json.Unmarshal(body, &value)
+
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveFields[key] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactValue(element)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,143 @@
+package ontap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestTraceRedactsSecrets(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	svm_uuid := simulator.AddSVM("svm_trace")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_trace")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := simulator.Client(t, ontap.WithSessionCookie()).WithContext(ctx)
+
+	// Changes are made by jobs
+	_, err := client.CreateQtree(&ontap.Qtree{
+		SVMUUID:        svm_uuid,
+		VolumeUUID:     volume_uuid,
+		Name:           "home",
+		SecurityStyle:  "unix",
+		UnixPermission: 755,
+	})
+
+	if err != nil {
+		t.Fatalf("unable to create qtree, got error: %s", err)
+	}
+
+	// Secrets are sent in request bodies
+	enabled := true
+	_, err = client.CreateISCSIService(&ontap.ISCSIService{SVM: &ontap.UUIDRef{UUID: svm_uuid}, Enabled: &enabled})
+
+	if err != nil {
+		t.Fatalf("unable to create iscsi service, got error: %s", err)
+	}
+
+	_, err = client.CreateISCSICredentials(&ontap.ISCSICredentials{
+		SVM:                &ontap.UUIDRef{UUID: svm_uuid},
+		Initiator:          "iqn.1998-01.com.example:trace",
+		AuthenticationType: "chap",
+		CHAP: &ontap.ISCSICredentialsCHAP{
+			Inbound: &ontap.ISCSICHAPUser{User: "trace", Password: "chap_inbound_secret"},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unable to create iscsi credentials, got error: %s", err)
+	}
+
+	// and received in response bodies
+	_, err = client.CreateS3Service(&ontap.S3Service{SVM: &ontap.UUIDRef{Name: "svm_trace"}, Name: "s3-trace"})
+
+	if err != nil {
+		t.Fatalf("unable to create s3 service, got error: %s", err)
+	}
+
+	user, err := client.CreateS3User(svm_uuid, &ontap.S3User{Name: "trace"})
+
+	if err != nil {
+		t.Fatalf("unable to create s3 user, got error: %s", err)
+	}
+
+	logs := output.String()
+
+	for _, secret := range []string{
+		"chap_inbound_secret",
+		user.AccessKey,
+		user.SecretKey,
+		base64.StdEncoding.EncodeToString([]byte("admin:password")),
+	} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("expected %s to be redacted from the logs", secret)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+
+	if err != nil {
+		t.Fatalf("unable to decode logs, got error: %s", err)
+	}
+
+	requests, responses, jobs := 0, 0, 0
+	redacted := map[string]bool{}
+
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "sending ONTAP request":
+			requests++
+			if entry["method"] == nil || entry["url"] == nil {
+				t.Errorf("expected the method and url of the request, got %v", entry)
+			}
+			headers, _ := entry["headers"].(map[string]interface{})
+			if headers["Authorization"] == "***" {
+				redacted["Authorization"] = true
+			}
+		case "received ONTAP response":
+			responses++
+			if entry["status"] == nil || entry["duration_ms"] == nil {
+				t.Errorf("expected the status and duration of the response, got %v", entry)
+			}
+			headers, _ := entry["headers"].(map[string]interface{})
+			if headers["Set-Cookie"] == "***" {
+				redacted["Set-Cookie"] = true
+			}
+		case "ONTAP job status":
+			jobs++
+			if entry["job_uuid"] == "" || entry["state"] != "success" {
+				t.Errorf("expected the uuid and state of the job, got %v", entry)
+			}
+		}
+	}
+
+	if requests == 0 || requests != responses {
+		t.Errorf("expected a response logged for each of the requests, got %d requests and %d responses", requests, responses)
+	}
+
+	if requests != len(simulator.Requests()) {
+		t.Errorf("expected the %d requests to be logged, got %d", len(simulator.Requests()), requests)
+	}
+
+	if jobs == 0 {
+		t.Errorf("expected the jobs to be logged")
+	}
+
+	// The session cookie is only returned in Set-Cookie, the jar adds it to
+	// requests after they are logged
+	for _, name := range []string{"Authorization", "Set-Cookie"} {
+		if !redacted[name] {
+			t.Errorf("expected the %s header to be logged redacted", name)
+		}
+	}
+}
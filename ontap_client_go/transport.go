@@ -0,0 +1,129 @@
+package ontap
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultMaxConnsPerHost is the number of connections kept open to the
+// cluster when WithMaxConnsPerHost isn't used
+const DefaultMaxConnsPerHost = 10
+
+// ClientOption customizes a Client created by NewClient
+type ClientOption func(*Client)
+
+// WithMaxConnsPerHost limits the number of connections opened to the cluster,
+// idle connections are kept alive up to that number to be reused by the
+// following requests
+func WithMaxConnsPerHost(max int) ClientOption {
+	return func(c *Client) {
+		if max > 0 {
+			c.maxConnsPerHost = max
+		}
+	}
+}
+
+// WithSessionCookie stores the session cookie returned by the cluster after
+// the first authenticated request, and sends it instead of the credentials
+// on the following requests. Credentials are sent again when the session
+// expires.
+func WithSessionCookie() ClientOption {
+	return func(c *Client) {
+		c.sessionCookie = true
+	}
+}
+
+// newTransport returns a keep-alive transport, attempting HTTP/2 when the
+// cluster supports it
+func newTransport(ignoreSSLErrors bool, maxConnsPerHost int) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: ignoreSSLErrors}
+
+	// A custom TLS configuration disables HTTP/2 unless it is forced
+	transport.ForceAttemptHTTP2 = true
+
+	transport.MaxConnsPerHost = maxConnsPerHost
+	transport.MaxIdleConnsPerHost = maxConnsPerHost
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return transport
+}
+
+// newHTTPClient returns the HTTP client of c, with a cookie jar when the
+// session cookie is used
+func (c *Client) newHTTPClient(ignoreSSLErrors bool) *http.Client {
+	http_client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: newTransport(ignoreSSLErrors, c.maxConnsPerHost),
+	}
+
+	if c.sessionCookie {
+		http_client.Jar = &sessionJar{}
+	}
+
+	return http_client
+}
+
+// hasSession returns true when a session cookie of a previous request is
+// stored
+func (c *Client) hasSession() bool {
+	jar, ok := c.HTTPClient.Jar.(*sessionJar)
+
+	return ok && len(jar.Cookies(nil)) > 0
+}
+
+// resetSession drops the stored session cookie, so that the credentials are
+// sent on the next request
+func (c *Client) resetSession() {
+	if jar, ok := c.HTTPClient.Jar.(*sessionJar); ok {
+		jar.reset()
+	}
+}
+
+// sessionJar is a cookie jar for a single cluster, requests of the client
+// only go to the management interface so cookies aren't matched on domain or
+// path
+type sessionJar struct {
+	mutex   sync.Mutex
+	cookies map[string]*http.Cookie
+}
+
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.cookies == nil {
+		j.cookies = map[string]*http.Cookie{}
+	}
+
+	for _, cookie := range cookies {
+		if cookie.MaxAge < 0 || cookie.Value == "" {
+			delete(j.cookies, cookie.Name)
+			continue
+		}
+		j.cookies[cookie.Name] = &http.Cookie{Name: cookie.Name, Value: cookie.Value}
+	}
+}
+
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	cookies := []*http.Cookie{}
+	for _, cookie := range j.cookies {
+		cookies = append(cookies, cookie)
+	}
+
+	return cookies
+}
+
+func (j *sessionJar) reset() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.cookies = nil
+}
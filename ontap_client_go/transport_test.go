@@ -0,0 +1,136 @@
+package ontap_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"testing"
+
+	ontap "github.com/ybizeul/terraform-provider-ontap/ontap_client_go"
+	"github.com/ybizeul/terraform-provider-ontap/ontap_client_go/ontaptest"
+)
+
+func TestSessionCookie(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	svm_uuid := simulator.AddSVM("svm_session")
+	volume_uuid := simulator.AddVolume(svm_uuid, "vol_session")
+
+	client := simulator.Client(t, ontap.WithSessionCookie())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSVMs(nil)
+
+		if err != nil {
+			t.Fatalf("unable to get svms, got error: %s", err)
+		}
+	}
+
+	if logins := simulator.Logins(); logins != 1 {
+		t.Errorf("expected the credentials to be sent once, got %d logins", logins)
+	}
+
+	// Requests are sent again with the credentials when the session has
+	// expired, with their body
+	simulator.ExpireSessions()
+
+	_, err := client.CreateQtree(&ontap.Qtree{
+		SVMUUID:        svm_uuid,
+		VolumeUUID:     volume_uuid,
+		Name:           "home",
+		SecurityStyle:  "unix",
+		UnixPermission: 755,
+	})
+
+	if err != nil {
+		t.Fatalf("unable to create qtree, got error: %s", err)
+	}
+
+	if simulator.Qtree(volume_uuid, "home") == nil {
+		t.Errorf("expected qtree home to be created")
+	}
+
+	if logins := simulator.Logins(); logins != 2 {
+		t.Errorf("expected a single login after the session expired, got %d logins", logins)
+	}
+}
+
+func TestWithoutSessionCookie(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	client := simulator.Client(t)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetSVMs(nil)
+
+		if err != nil {
+			t.Fatalf("unable to get svms, got error: %s", err)
+		}
+	}
+
+	if logins := simulator.Logins(); logins != 3 {
+		t.Errorf("expected the credentials to be sent with every request, got %d logins", logins)
+	}
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	host := simulator.Host()
+	username := "admin"
+	password := "wrong"
+
+	client, err := ontap.NewClient(&host, &username, &password, true, ontap.WithSessionCookie())
+
+	if err != nil {
+		t.Fatalf("unable to create client, got error: %s", err)
+	}
+
+	_, err = client.GetSVMs(nil)
+
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected a 401 error, got: %v", err)
+	}
+
+	if logins := simulator.Logins(); logins != 0 {
+		t.Errorf("expected no login, got %d", logins)
+	}
+}
+
+func TestConnectionReuse(t *testing.T) {
+	simulator := ontaptest.NewSimulator()
+	defer simulator.Close()
+
+	client := simulator.Client(t, ontap.WithMaxConnsPerHost(4))
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok || transport.MaxConnsPerHost != 4 || transport.MaxIdleConnsPerHost != 4 {
+		t.Fatalf("expected a transport keeping 4 connections, got %#v", client.HTTPClient.Transport)
+	}
+
+	connections, reused := 0, 0
+	ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			connections++
+			if info.Reused {
+				reused++
+			}
+		},
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := client.WithContext(ctx).GetSVMs(nil)
+
+		if err != nil {
+			t.Fatalf("unable to get svms, got error: %s", err)
+		}
+	}
+
+	if connections != 5 || reused != 4 {
+		t.Errorf("expected the first connection to be reused by the 4 following requests, got %d reused out of %d", reused, connections)
+	}
+}
@@ -0,0 +1,204 @@
+package ontap
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultBasePath is the path of the ONTAP REST API on the cluster management
+// interface
+const DefaultBasePath = "/api"
+
+// ParseHost parses the hostname of a cluster, which is either a host name or
+// address with an optional port, i.e. cluster1, 10.0.0.1:8443 or
+// [fe80::1]:8443, or a URL with a scheme, a port and a path prefix, i.e.
+// http://proxy:8080/ontap. The REST API is expected under /api below the path
+// prefix, unless the path already ends with /api.
+func ParseHost(host string) (*url.URL, error) {
+	if strings.TrimSpace(host) == "" {
+		return nil, errors.New("hostname is empty")
+	}
+
+	// A bare IPv6 address would be read as a host and a port
+	if ip := net.ParseIP(host); ip != nil && strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	host_url, err := url.Parse(host)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if host_url.Scheme != "https" && host_url.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported scheme %q, use https or http", host_url.Scheme)
+	}
+
+	if host_url.Hostname() == "" {
+		return nil, fmt.Errorf("no host in %q", host)
+	}
+
+	if host_url.User != nil {
+		return nil, errors.New("credentials must be set with username and password, not in the hostname")
+	}
+
+	if host_url.RawQuery != "" || host_url.Fragment != "" {
+		return nil, fmt.Errorf("query and fragment aren't supported in %q", host)
+	}
+
+	if port := host_url.Port(); port != "" {
+		port_number, err := strconv.Atoi(port)
+
+		if err != nil || port_number < 1 || port_number > 65535 {
+			return nil, fmt.Errorf("invalid port %q", port)
+		}
+	}
+
+	return host_url, nil
+}
+
+// setHost sets the scheme, host, port and base path of c from the hostname
+// of the cluster
+func (c *Client) setHost(host string) error {
+	host_url, err := ParseHost(host)
+
+	if err != nil {
+		return err
+	}
+
+	c.Scheme = host_url.Scheme
+	c.HostURL = host_url.Hostname()
+
+	if port := host_url.Port(); port != "" {
+		c.Port, _ = strconv.Atoi(port)
+	}
+
+	if base_path := strings.TrimRight(host_url.Path, "/"); base_path != "" {
+		if !strings.HasSuffix(base_path, DefaultBasePath) {
+			base_path += DefaultBasePath
+		}
+		c.BasePath = base_path
+	}
+
+	return nil
+}
+
+// restURL returns the URL of the REST endpoint at path, relative to the base
+// path, with the query parameters encoded. Values inserted in path, like
+// names, must be escaped with url.PathEscape.
+func (c *Client) restURL(path string, query url.Values) string {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+
+	host := c.HostURL
+	if c.Port != 0 {
+		host = net.JoinHostPort(c.HostURL, strconv.Itoa(c.Port))
+	} else if strings.Contains(host, ":") {
+		// IPv6 address
+		host = "[" + host + "]"
+	}
+
+	rest_url := fmt.Sprintf("%s://%s%s%s", scheme, host, c.basePath(), path)
+
+	if len(query) > 0 {
+		rest_url = fmt.Sprintf("%s?%s", rest_url, query.Encode())
+	}
+
+	return rest_url
+}
+
+// linkURL returns the URL of an href returned by ONTAP, i.e. the next page of
+// a collection or a job. Links always start with /api, which is replaced by
+// the base path of the client.
+func (c *Client) linkURL(href string) string {
+	path, query, found := strings.Cut(href, "?")
+
+	link_url := c.restURL(strings.TrimPrefix(path, DefaultBasePath), nil)
+
+	if found {
+		link_url = fmt.Sprintf("%s?%s", link_url, query)
+	}
+
+	return link_url
+}
+
+func (c *Client) basePath() string {
+	if c.BasePath == "" {
+		return DefaultBasePath
+	}
+
+	base_path := strings.Trim(c.BasePath, "/")
+	if base_path == "" {
+		return ""
+	}
+
+	return "/" + base_path
+}
+
+// ontapQuerySpecials are the characters ONTAP interprets in query values,
+// the Query helpers below use them explicitly while QueryLiteral matches a
+// value as is
+const ontapQuerySpecials = `*|!<>"`
+
+// QueryLiteral returns a query value matching value exactly, quoting it when
+// it contains characters ONTAP would interpret as operators
+func QueryLiteral(value string) string {
+	if !strings.ContainsAny(value, ontapQuerySpecials) && !strings.Contains(value, "..") {
+		return value
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// QueryWildcard returns a query value matching pattern, where * matches any
+// sequence of characters. Other special characters are matched literally.
+func QueryWildcard(pattern string) string {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = QueryLiteral(part)
+		}
+	}
+	return strings.Join(parts, "*")
+}
+
+// QueryOr returns a query value matching any of values
+func QueryOr(values ...string) string {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = QueryLiteral(value)
+	}
+	return strings.Join(literals, "|")
+}
+
+// QueryNot returns a query value matching anything but value
+func QueryNot(value string) string {
+	return "!" + QueryLiteral(value)
+}
+
+// QueryRange returns a query value matching numbers between min and max,
+// inclusive
+func QueryRange(min int64, max int64) string {
+	return fmt.Sprintf("%d..%d", min, max)
+}
+
+// QueryAtLeast returns a query value matching numbers greater than or equal
+// to min
+func QueryAtLeast(min int64) string {
+	return fmt.Sprintf(">=%d", min)
+}
+
+// QueryAtMost returns a query value matching numbers lower than or equal to
+// max
+func QueryAtMost(max int64) string {
+	return fmt.Sprintf("<=%d", max)
+}